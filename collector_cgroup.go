@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CgroupCollector 基于 cgroup v2 的服务和容器资源采集器，不依赖 Docker daemon
+type CgroupCollector struct {
+	config   CgroupConfig
+	prev     map[string]cgroupSample
+	prevTime time.Time
+}
+
+type cgroupSample struct {
+	cpuUsageUsec uint64
+	ioReadBytes  uint64
+	ioWriteBytes uint64
+}
+
+var cgroupContainerScopePattern = regexp.MustCompile(`^(?:docker|cri-containerd|crio|libpod)-([0-9a-f]{64})\.scope$`)
+var cgroupContainerIDPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func NewCgroupCollector(config CgroupConfig) *CgroupCollector {
+	if config.Root == "" {
+		config.Root = "/sys/fs/cgroup"
+	}
+	return &CgroupCollector{
+		config: config,
+		prev:   make(map[string]cgroupSample),
+	}
+}
+
+func (c *CgroupCollector) Name() string {
+	return "cgroup"
+}
+
+func (c *CgroupCollector) Collect() (interface{}, error) {
+	return c.collectAt(time.Now())
+}
+
+func (c *CgroupCollector) collectAt(now time.Time) (*CgroupMetrics, error) {
+	metrics := &CgroupMetrics{Groups: []CgroupInfo{}}
+	if !c.config.Enabled {
+		return metrics, nil
+	}
+	// 只支持 cgroup v2 统一层级，v1 主机直接返回空结果
+	if _, err := os.Stat(filepath.Join(c.config.Root, "cgroup.controllers")); err != nil {
+		return metrics, nil
+	}
+	metrics.Supported = true
+
+	elapsed := now.Sub(c.prevTime).Seconds()
+	if c.prevTime.IsZero() {
+		elapsed = 0
+	}
+	current := make(map[string]cgroupSample)
+
+	err := filepath.WalkDir(c.config.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(c.config.Root, path)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = "/" + filepath.ToSlash(rel)
+		kind, containerID, unit := cgroupWorkload(rel)
+		if kind == "" {
+			return nil
+		}
+
+		info := readCgroupInfo(path)
+		info.Path = rel
+		info.Kind = kind
+		info.ContainerID = containerID
+		info.Unit = unit
+		if containerID != "" {
+			info.Name = shortContainerID(containerID)
+		} else {
+			info.Name = unit
+		}
+
+		sample := cgroupSample{
+			cpuUsageUsec: info.CPUUsageUsec,
+			ioReadBytes:  info.IOReadBytes,
+			ioWriteBytes: info.IOWriteBytes,
+		}
+		if prev, ok := c.prev[rel]; ok && elapsed > 0 {
+			info.CPUPercent = counterRate(prev.cpuUsageUsec, sample.cpuUsageUsec, elapsed) / 1e6 * 100
+			info.IOReadBytesPerSec = counterRate(prev.ioReadBytes, sample.ioReadBytes, elapsed)
+			info.IOWriteBytesPerSec = counterRate(prev.ioWriteBytes, sample.ioWriteBytes, elapsed)
+		}
+		current[rel] = sample
+		metrics.Groups = append(metrics.Groups, info)
+
+		// 容器/服务内部的子 cgroup 已被父级统计，不再重复上报
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.prev = current
+	c.prevTime = now
+
+	sort.Slice(metrics.Groups, func(i, j int) bool {
+		return metrics.Groups[i].Path < metrics.Groups[j].Path
+	})
+	return metrics, nil
+}
+
+// cgroupWorkload 将 cgroup 路径映射为工作负载类型、容器ID或 systemd unit
+func cgroupWorkload(path string) (kind, containerID, unit string) {
+	path = strings.TrimSuffix(path, "/")
+	base := path[strings.LastIndex(path, "/")+1:]
+
+	if match := cgroupContainerScopePattern.FindStringSubmatch(base); match != nil {
+		return "container", match[1], ""
+	}
+	// cgroupfs 驱动: /docker/<id>、/kubepods/burstable/pod<uid>/<id>
+	if cgroupContainerIDPattern.MatchString(base) {
+		return "container", base, ""
+	}
+	if strings.HasPrefix(path, "/system.slice/") && strings.HasSuffix(base, ".service") {
+		return "service", "", base
+	}
+	return "", "", ""
+}
+
+func readCgroupInfo(dir string) CgroupInfo {
+	var info CgroupInfo
+
+	cpuStat := readCgroupKeyValues(filepath.Join(dir, "cpu.stat"))
+	info.CPUUsageUsec = cpuStat["usage_usec"]
+	info.CPUThrottledUsec = cpuStat["throttled_usec"]
+
+	info.MemoryCurrent = readCgroupUint(filepath.Join(dir, "memory.current"))
+	info.MemoryMax = readCgroupUint(filepath.Join(dir, "memory.max"))
+	if info.MemoryMax > 0 {
+		info.MemoryPercent = float64(info.MemoryCurrent) / float64(info.MemoryMax) * 100
+	}
+	memoryPressure, _ := parsePressureFile(filepath.Join(dir, "memory.pressure"))
+	info.MemoryPressureSomeAvg10 = memoryPressure.Some.Avg10
+	info.MemoryPressureFullAvg10 = memoryPressure.Full.Avg10
+	cpuPressure, _ := parsePressureFile(filepath.Join(dir, "cpu.pressure"))
+	info.CPUPressureSomeAvg10 = cpuPressure.Some.Avg10
+	ioPressure, _ := parsePressureFile(filepath.Join(dir, "io.pressure"))
+	info.IOPressureSomeAvg10 = ioPressure.Some.Avg10
+	info.IOPressureFullAvg10 = ioPressure.Full.Avg10
+
+	info.IOReadBytes, info.IOWriteBytes = readCgroupIOStat(filepath.Join(dir, "io.stat"))
+	info.PidsCurrent = readCgroupUint(filepath.Join(dir, "pids.current"))
+	return info
+}
+
+// readCgroupUint 读取单值文件，"max" 表示不限制，返回0
+func readCgroupUint(path string) uint64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	value, _ := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return value
+}
+
+func readCgroupKeyValues(path string) map[string]uint64 {
+	values := make(map[string]uint64)
+	file, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if value, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = value
+		}
+	}
+	return values
+}
+
+// readCgroupIOStat 汇总 io.stat 中所有设备的读写字节数
+func readCgroupIOStat(path string) (uint64, uint64) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+
+	var read, write uint64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		for _, field := range strings.Fields(scanner.Text()) {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			parsed, _ := strconv.ParseUint(value, 10, 64)
+			switch key {
+			case "rbytes":
+				read += parsed
+			case "wbytes":
+				write += parsed
+			}
+		}
+	}
+	return read, write
+}
+
+// counterRate 计算单调计数器每秒增量，计数器回退（重启、重建）时返回0
+func counterRate(prev, current uint64, seconds float64) float64 {
+	if seconds <= 0 || current < prev {
+		return 0
+	}
+	return float64(current-prev) / seconds
+}
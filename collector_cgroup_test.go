@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testContainerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func writeCgroupFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir %s: %v", dir, err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func TestCgroupWorkloadMapsPaths(t *testing.T) {
+	tests := []struct {
+		path        string
+		kind        string
+		containerID string
+		unit        string
+	}{
+		{"/system.slice/nginx.service", "service", "", "nginx.service"},
+		{"/system.slice/docker-" + testContainerID + ".scope", "container", testContainerID, ""},
+		{"/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-podabc.slice/cri-containerd-" + testContainerID + ".scope", "container", testContainerID, ""},
+		{"/docker/" + testContainerID, "container", testContainerID, ""},
+		{"/user.slice/user-1000.slice", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		kind, containerID, unit := cgroupWorkload(tt.path)
+		if kind != tt.kind || containerID != tt.containerID || unit != tt.unit {
+			t.Fatalf("cgroupWorkload(%q) = (%q, %q, %q), want (%q, %q, %q)", tt.path, kind, containerID, unit, tt.kind, tt.containerID, tt.unit)
+		}
+	}
+}
+
+func TestCgroupCollectorReadsFakeTree(t *testing.T) {
+	root := t.TempDir()
+	writeCgroupFiles(t, root, map[string]string{"cgroup.controllers": "cpu memory io pids\n"})
+	serviceDir := filepath.Join(root, "system.slice", "nginx.service")
+	writeCgroupFiles(t, serviceDir, map[string]string{
+		"cpu.stat":        "usage_usec 1000000\nuser_usec 600000\nsystem_usec 400000\n",
+		"memory.current":  "104857600\n",
+		"memory.max":      "419430400\n",
+		"memory.pressure": "some avg10=1.50 avg60=0.80 avg300=0.20 total=12345\nfull avg10=0.50 avg60=0.10 avg300=0.00 total=2345\n",
+		"io.pressure":     "some avg10=3.00 avg60=1.00 avg300=0.50 total=999\nfull avg10=2.00 avg60=0.50 avg300=0.10 total=555\n",
+		"io.stat":         "8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=4096 wbytes=0 rios=1 wios=0 dbytes=0 dios=0\n",
+		"pids.current":    "12\n",
+	})
+	containerDir := filepath.Join(root, "system.slice", "docker-"+testContainerID+".scope")
+	writeCgroupFiles(t, containerDir, map[string]string{
+		"cpu.stat":       "usage_usec 500000\n",
+		"memory.current": "2048\n",
+		"memory.max":     "max\n",
+	})
+	writeCgroupFiles(t, filepath.Join(root, "user.slice"), map[string]string{"cpu.stat": "usage_usec 1\n"})
+
+	collector := NewCgroupCollector(CgroupConfig{Enabled: true, Root: root})
+	start := time.Unix(1000, 0)
+	if _, err := collector.collectAt(start); err != nil {
+		t.Fatalf("first collect: %v", err)
+	}
+	writeCgroupFiles(t, serviceDir, map[string]string{"cpu.stat": "usage_usec 3000000\n"})
+
+	metrics, err := collector.collectAt(start.Add(10 * time.Second))
+	if err != nil {
+		t.Fatalf("second collect: %v", err)
+	}
+	if !metrics.Supported {
+		t.Fatal("expected cgroup v2 to be detected")
+	}
+	if len(metrics.Groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d: %#v", len(metrics.Groups), metrics.Groups)
+	}
+
+	byKind := make(map[string]CgroupInfo)
+	for _, group := range metrics.Groups {
+		byKind[group.Kind] = group
+	}
+	service := byKind["service"]
+	if service.Unit != "nginx.service" || service.Name != "nginx.service" {
+		t.Fatalf("unexpected service mapping: %#v", service)
+	}
+	if service.CPUPercent != 20 {
+		t.Fatalf("expected 20%% cpu from 2s usage over 10s, got %.2f", service.CPUPercent)
+	}
+	if service.MemoryPercent != 25 {
+		t.Fatalf("expected memory percent 25, got %.2f", service.MemoryPercent)
+	}
+	if service.MemoryPressureSomeAvg10 != 1.5 || service.MemoryPressureFullAvg10 != 0.5 {
+		t.Fatalf("unexpected memory pressure: some=%.2f full=%.2f", service.MemoryPressureSomeAvg10, service.MemoryPressureFullAvg10)
+	}
+	if service.IOPressureSomeAvg10 != 3 || service.IOPressureFullAvg10 != 2 {
+		t.Fatalf("unexpected io pressure: some=%.2f full=%.2f", service.IOPressureSomeAvg10, service.IOPressureFullAvg10)
+	}
+	if service.IOReadBytes != 8192 || service.IOWriteBytes != 8192 {
+		t.Fatalf("unexpected io bytes: read=%d write=%d", service.IOReadBytes, service.IOWriteBytes)
+	}
+	if service.PidsCurrent != 12 {
+		t.Fatalf("expected 12 pids, got %d", service.PidsCurrent)
+	}
+
+	container := byKind["container"]
+	if container.ContainerID != testContainerID || container.Name != testContainerID[:12] {
+		t.Fatalf("unexpected container mapping: %#v", container)
+	}
+	if container.MemoryMax != 0 || container.MemoryPercent != 0 {
+		t.Fatalf("expected unlimited memory.max to report 0, got max=%d percent=%.2f", container.MemoryMax, container.MemoryPercent)
+	}
+}
+
+func TestCgroupCollectorWithoutUnifiedHierarchy(t *testing.T) {
+	collector := NewCgroupCollector(CgroupConfig{Enabled: true, Root: t.TempDir()})
+
+	metrics, err := collector.collectAt(time.Now())
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if metrics.Supported || len(metrics.Groups) != 0 {
+		t.Fatalf("expected unsupported empty metrics, got %#v", metrics)
+	}
+}
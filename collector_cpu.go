@@ -4,14 +4,18 @@
 package main
 
 import (
-	"time"
+	"runtime"
 
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/load"
 )
 
 // CPUCollector CPU采集器
-type CPUCollector struct{}
+// 保存上一次的 cpu.Times 快照，用两次采集之间的差值计算使用率，不再阻塞等待
+type CPUCollector struct {
+	prevTotal  cpu.TimesStat
+	prevPerCPU []cpu.TimesStat
+}
 
 // Name 返回采集器名称
 func (c *CPUCollector) Name() string {
@@ -20,11 +24,14 @@ func (c *CPUCollector) Name() string {
 
 // Collect 采集CPU指标
 func (c *CPUCollector) Collect() (interface{}, error) {
-	// CPU使用率
-	percent, err := cpu.Percent(time.Second, false)
+	totalTimes, err := cpu.Times(false)
 	if err != nil {
 		return nil, err
 	}
+	perCPUTimes, err := cpu.Times(true)
+	if err != nil {
+		perCPUTimes = nil
+	}
 
 	// 负载平均值
 	loadAvg, err := load.Avg()
@@ -39,12 +46,98 @@ func (c *CPUCollector) Collect() (interface{}, error) {
 	}
 
 	metrics := &CPUMetrics{
-		UsagePercent: percent[0],
-		LoadAvg1:     loadAvg.Load1,
-		LoadAvg5:     loadAvg.Load5,
-		LoadAvg15:    loadAvg.Load15,
-		CoreCount:    coreCount,
+		LoadAvg1:  loadAvg.Load1,
+		LoadAvg5:  loadAvg.Load5,
+		LoadAvg15: loadAvg.Load15,
+		CoreCount: coreCount,
+	}
+
+	// 首次采集没有上一次快照，与零值比较得到开机以来的平均值
+	if len(totalTimes) > 0 {
+		breakdown := cpuTimesBreakdown(c.prevTotal, totalTimes[0])
+		metrics.UsagePercent = breakdown.usage
+		metrics.UserPercent = breakdown.user
+		metrics.SystemPercent = breakdown.system
+		metrics.NicePercent = breakdown.nice
+		metrics.IowaitPercent = breakdown.iowait
+		metrics.IrqPercent = breakdown.irq
+		metrics.SoftirqPercent = breakdown.softirq
+		metrics.StealPercent = breakdown.steal
+		metrics.GuestPercent = breakdown.guest
+		c.prevTotal = totalTimes[0]
+	}
+
+	if len(perCPUTimes) > 0 {
+		metrics.PerCorePercent = make([]float64, len(perCPUTimes))
+		for i, times := range perCPUTimes {
+			var prev cpu.TimesStat
+			if i < len(c.prevPerCPU) && c.prevPerCPU[i].CPU == times.CPU {
+				prev = c.prevPerCPU[i]
+			}
+			metrics.PerCorePercent[i] = cpuTimesBreakdown(prev, times).usage
+		}
+		c.prevPerCPU = perCPUTimes
 	}
 
 	return metrics, nil
 }
+
+type cpuBreakdown struct {
+	usage   float64
+	user    float64
+	system  float64
+	nice    float64
+	iowait  float64
+	irq     float64
+	softirq float64
+	steal   float64
+	guest   float64
+}
+
+// cpuTimesBreakdown 根据两次 cpu.Times 快照计算各模式占比（百分比）
+func cpuTimesBreakdown(prev, current cpu.TimesStat) cpuBreakdown {
+	total := cpuTimesTotal(current) - cpuTimesTotal(prev)
+	if total <= 0 {
+		return cpuBreakdown{}
+	}
+	percent := func(prevValue, currentValue float64) float64 {
+		delta := currentValue - prevValue
+		if delta <= 0 {
+			return 0
+		}
+		return clampPercent(delta / total * 100)
+	}
+
+	breakdown := cpuBreakdown{
+		user:    percent(prev.User, current.User),
+		system:  percent(prev.System, current.System),
+		nice:    percent(prev.Nice, current.Nice),
+		iowait:  percent(prev.Iowait, current.Iowait),
+		irq:     percent(prev.Irq, current.Irq),
+		softirq: percent(prev.Softirq, current.Softirq),
+		steal:   percent(prev.Steal, current.Steal),
+		guest:   percent(prev.Guest, current.Guest),
+	}
+	idle := percent(prev.Idle, current.Idle)
+	breakdown.usage = clampPercent(100 - idle - breakdown.iowait)
+	return breakdown
+}
+
+// cpuTimesTotal 与 gopsutil 口径一致：Linux 上 guest 时间已计入 user/nice，需要扣除
+func cpuTimesTotal(t cpu.TimesStat) float64 {
+	total := t.Total()
+	if runtime.GOOS == "linux" {
+		total -= t.Guest + t.GuestNice
+	}
+	return total
+}
+
+func clampPercent(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+	if value > 100 {
+		return 100
+	}
+	return value
+}
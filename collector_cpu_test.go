@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+func TestCPUTimesBreakdownUsesDeltas(t *testing.T) {
+	prev := cpu.TimesStat{CPU: "cpu-total", User: 100, System: 50, Idle: 800, Iowait: 10, Steal: 5}
+	current := cpu.TimesStat{CPU: "cpu-total", User: 130, System: 60, Idle: 845, Iowait: 15, Irq: 2, Softirq: 3, Steal: 10}
+
+	breakdown := cpuTimesBreakdown(prev, current)
+
+	want := map[string][2]float64{
+		"user":    {breakdown.user, 30},
+		"system":  {breakdown.system, 10},
+		"iowait":  {breakdown.iowait, 5},
+		"irq":     {breakdown.irq, 2},
+		"softirq": {breakdown.softirq, 3},
+		"steal":   {breakdown.steal, 5},
+		"usage":   {breakdown.usage, 50},
+	}
+	for name, pair := range want {
+		if math.Abs(pair[0]-pair[1]) > 1e-9 {
+			t.Fatalf("%s = %.4f, want %.4f", name, pair[0], pair[1])
+		}
+	}
+}
+
+func TestCPUTimesBreakdownWithoutElapsedTime(t *testing.T) {
+	times := cpu.TimesStat{User: 10, Idle: 90}
+	if got := cpuTimesBreakdown(times, times); got != (cpuBreakdown{}) {
+		t.Fatalf("expected zero breakdown without elapsed time, got %#v", got)
+	}
+}
+
+func TestCPUCollectorDoesNotBlock(t *testing.T) {
+	collector := &CPUCollector{}
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := collector.Collect(); err != nil {
+			t.Skipf("cpu times unavailable: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected non-blocking collection, took %v", elapsed)
+	}
+}
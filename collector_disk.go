@@ -4,11 +4,40 @@
 package main
 
 import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/shirou/gopsutil/v3/disk"
 )
 
 // DiskCollector 磁盘采集器
-type DiskCollector struct{}
+// 保存上一次的 IO 计数，用差值计算吞吐、IOPS、延迟和利用率
+type DiskCollector struct {
+	config     DiskConfig
+	prevIO     map[string]disk.IOCountersStat
+	prevTime   time.Time
+	forecaster *diskForecaster
+}
+
+// NewDiskCollector 创建磁盘采集器
+func NewDiskCollector(config DiskConfig) *DiskCollector {
+	collector := &DiskCollector{
+		config: config,
+		prevIO: make(map[string]disk.IOCountersStat),
+	}
+	if config.ForecastEnabled {
+		window := time.Duration(config.ForecastWindow) * time.Second
+		if window <= 0 {
+			window = 6 * time.Hour
+		}
+		collector.forecaster = newDiskForecaster(config.ForecastStateFile, window)
+	}
+	return collector
+}
 
 // Name 返回采集器名称
 func (c *DiskCollector) Name() string {
@@ -21,9 +50,11 @@ func (c *DiskCollector) Collect() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	partitions = filterPartitions(partitions, c.config)
 
 	metrics := &DiskMetrics{
 		Partitions: make([]PartitionMetrics, 0),
+		IO:         make([]DiskIOMetrics, 0),
 	}
 
 	for _, partition := range partitions {
@@ -32,17 +63,241 @@ func (c *DiskCollector) Collect() (interface{}, error) {
 			continue
 		}
 
-		pm := PartitionMetrics{
-			Device:      partition.Device,
-			Mountpoint:  partition.Mountpoint,
-			Fstype:      partition.Fstype,
-			Total:       usage.Total,
-			Used:        usage.Used,
-			Free:        usage.Free,
-			UsedPercent: usage.UsedPercent,
-		}
-		metrics.Partitions = append(metrics.Partitions, pm)
+		metrics.Partitions = append(metrics.Partitions, partitionMetricsFromUsage(partition, usage))
+	}
+
+	if c.forecaster != nil {
+		c.forecaster.observe(metrics.Partitions, time.Now())
+	}
+
+	// IO 统计失败不影响容量数据
+	if counters, err := disk.IOCounters(); err == nil {
+		metrics.IO = c.collectIO(counters, diskDeviceMountpoints(partitions), time.Now())
 	}
 
 	return metrics, nil
 }
+
+func partitionMetricsFromUsage(partition disk.PartitionStat, usage *disk.UsageStat) PartitionMetrics {
+	return PartitionMetrics{
+		Device:            partition.Device,
+		Mountpoint:        partition.Mountpoint,
+		Fstype:            partition.Fstype,
+		Total:             usage.Total,
+		Used:              usage.Used,
+		Free:              usage.Free,
+		UsedPercent:       usage.UsedPercent,
+		InodesTotal:       usage.InodesTotal,
+		InodesUsed:        usage.InodesUsed,
+		InodesFree:        usage.InodesFree,
+		InodesUsedPercent: usage.InodesUsedPercent,
+		SecondsUntilFull:  -1,
+	}
+}
+
+// filterPartitions 按挂载点、设备、文件系统类型的包含/排除规则过滤分区
+// 同一块设备挂载在多个位置（bind mount）时只保留路径最短的挂载点
+// 只对 /dev/ 下的块设备去重，tmpfs、overlay 等设备名相同的伪文件系统是各自独立的挂载
+func filterPartitions(partitions []disk.PartitionStat, config DiskConfig) []disk.PartitionStat {
+	result := make([]disk.PartitionStat, 0, len(partitions))
+	for _, partition := range partitions {
+		if !matchIncludeExclude(partition.Mountpoint, config.IncludeMountpoints, config.ExcludeMountpoints) ||
+			!matchIncludeExclude(partition.Device, config.IncludeDevices, config.ExcludeDevices) ||
+			!matchIncludeExclude(partition.Fstype, config.IncludeFstypes, config.ExcludeFstypes) {
+			continue
+		}
+		result = append(result, partition)
+	}
+	if !config.DedupeDevices {
+		return result
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i].Mountpoint) < len(result[j].Mountpoint)
+	})
+	seen := make(map[string]bool)
+	deduped := result[:0]
+	for _, partition := range result {
+		if strings.HasPrefix(partition.Device, "/dev/") {
+			if seen[partition.Device] {
+				continue
+			}
+			seen[partition.Device] = true
+		}
+		deduped = append(deduped, partition)
+	}
+	sort.SliceStable(deduped, func(i, j int) bool {
+		return deduped[i].Mountpoint < deduped[j].Mountpoint
+	})
+	return deduped
+}
+
+// matchIncludeExclude 排除规则优先；配置了包含规则时必须命中其中之一
+func matchIncludeExclude(value string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if matchGlob(pattern, value) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if matchGlob(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+var globCache sync.Map
+
+// matchGlob 路径通配：* 和 ? 不跨越 "/"，** 匹配任意多级目录
+func matchGlob(pattern, value string) bool {
+	if cached, ok := globCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp).MatchString(value)
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "/**/" 同时匹配零级目录
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					expr.WriteString("(?:.*/)?")
+				} else {
+					expr.WriteString(".*")
+				}
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				class := pattern[i+1 : i+end]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				expr.WriteString("[" + class + "]")
+				i += end
+			} else {
+				expr.WriteString(regexp.QuoteMeta("["))
+			}
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return pattern == value
+	}
+	globCache.Store(pattern, re)
+	return re.MatchString(value)
+}
+
+// collectIO 计算每个块设备的 IO 速率，首次采集只保存快照
+func (c *DiskCollector) collectIO(counters map[string]disk.IOCountersStat, mountpoints map[string][]string, now time.Time) []DiskIOMetrics {
+	result := make([]DiskIOMetrics, 0, len(counters))
+	elapsed := now.Sub(c.prevTime).Seconds()
+
+	for name, current := range counters {
+		if isVirtualBlockDevice(name) {
+			continue
+		}
+		prev, ok := c.prevIO[name]
+		if !ok || c.prevTime.IsZero() {
+			continue
+		}
+		io := diskIORates(prev, current, elapsed)
+		io.Device = name
+		io.Label = current.Label
+		io.Mountpoints = mountpoints[name]
+		if len(io.Mountpoints) == 0 && current.Label != "" {
+			io.Mountpoints = mountpoints[current.Label]
+		}
+		result = append(result, io)
+	}
+
+	c.prevIO = counters
+	c.prevTime = now
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Device < result[j].Device
+	})
+	return result
+}
+
+// diskIORates 按 iostat 口径计算两次计数之间的吞吐、IOPS、await、平均队列长度和 %util
+func diskIORates(prev, current disk.IOCountersStat, seconds float64) DiskIOMetrics {
+	io := DiskIOMetrics{IopsInProgress: current.IopsInProgress}
+	if seconds <= 0 {
+		return io
+	}
+
+	io.ReadBytesPerSec = counterRate(prev.ReadBytes, current.ReadBytes, seconds)
+	io.WriteBytesPerSec = counterRate(prev.WriteBytes, current.WriteBytes, seconds)
+	io.ReadIOPS = counterRate(prev.ReadCount, current.ReadCount, seconds)
+	io.WriteIOPS = counterRate(prev.WriteCount, current.WriteCount, seconds)
+
+	reads := counterDelta(prev.ReadCount, current.ReadCount)
+	writes := counterDelta(prev.WriteCount, current.WriteCount)
+	readTime := counterDelta(prev.ReadTime, current.ReadTime)
+	writeTime := counterDelta(prev.WriteTime, current.WriteTime)
+	if reads > 0 {
+		io.ReadAwaitMs = float64(readTime) / float64(reads)
+	}
+	if writes > 0 {
+		io.WriteAwaitMs = float64(writeTime) / float64(writes)
+	}
+	if reads+writes > 0 {
+		io.AwaitMs = float64(readTime+writeTime) / float64(reads+writes)
+	}
+
+	elapsedMs := seconds * 1000
+	io.QueueDepth = float64(counterDelta(prev.WeightedIO, current.WeightedIO)) / elapsedMs
+	io.UtilPercent = clampPercent(float64(counterDelta(prev.IoTime, current.IoTime)) / elapsedMs * 100)
+	return io
+}
+
+func counterDelta(prev, current uint64) uint64 {
+	if current < prev {
+		return 0
+	}
+	return current - prev
+}
+
+// diskDeviceMountpoints 建立块设备名到挂载点的映射
+// /dev/mapper/vg-root 同时按 dm 名称（与 IOCounters 的 Label 一致）和解析后的 dm-N 记录
+func diskDeviceMountpoints(partitions []disk.PartitionStat) map[string][]string {
+	result := make(map[string][]string)
+	add := func(key, mountpoint string) {
+		for _, existing := range result[key] {
+			if existing == mountpoint {
+				return
+			}
+		}
+		result[key] = append(result[key], mountpoint)
+	}
+
+	for _, partition := range partitions {
+		if !strings.HasPrefix(partition.Device, "/dev/") {
+			continue
+		}
+		add(filepath.Base(partition.Device), partition.Mountpoint)
+		if resolved, err := filepath.EvalSymlinks(partition.Device); err == nil {
+			add(filepath.Base(resolved), partition.Mountpoint)
+		}
+	}
+	return result
+}
+
+func isVirtualBlockDevice(name string) bool {
+	return strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram")
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	diskForecastSampleInterval = time.Minute     // 历史采样最小间隔，避免状态文件过大
+	diskForecastMinSamples     = 3               // 最少样本数
+	diskForecastMinSpan        = 5 * time.Minute // 样本最少覆盖时长
+	diskForecastExpireAfter    = 6 * time.Hour   // 挂载点超过该时长未出现才丢弃历史
+)
+
+// diskForecaster 按挂载点保存已用空间历史，用最小二乘拟合增长趋势并预测写满时间
+// 历史持久化到本地文件，Agent 重启后继续使用
+type diskForecaster struct {
+	path    string
+	window  time.Duration
+	history map[string][]diskUsageSample
+}
+
+type diskUsageSample struct {
+	Timestamp int64  `json:"timestamp"`
+	Used      uint64 `json:"used"`
+}
+
+func newDiskForecaster(path string, window time.Duration) *diskForecaster {
+	f := &diskForecaster{
+		path:    path,
+		window:  window,
+		history: make(map[string][]diskUsageSample),
+	}
+	if path == "" {
+		return f
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &f.history); err != nil {
+			log.Printf("Failed to load disk forecast state %s: %v", path, err)
+			f.history = make(map[string][]diskUsageSample)
+		}
+	}
+	return f
+}
+
+// observe 记录本次采样并填充每个分区的增长速率和预计写满时间
+func (f *diskForecaster) observe(partitions []PartitionMetrics, now time.Time) {
+	changed := false
+	active := make(map[string]bool, len(partitions))
+	cutoff := now.Add(-f.window).Unix()
+
+	for i := range partitions {
+		p := &partitions[i]
+		active[p.Mountpoint] = true
+
+		samples := f.history[p.Mountpoint]
+		if n := len(samples); n == 0 || now.Unix()-samples[n-1].Timestamp >= int64(diskForecastSampleInterval/time.Second) {
+			samples = append(samples, diskUsageSample{Timestamp: now.Unix(), Used: p.Used})
+			changed = true
+		}
+		for len(samples) > 0 && samples[0].Timestamp < cutoff {
+			samples = samples[1:]
+			changed = true
+		}
+		f.history[p.Mountpoint] = samples
+
+		p.GrowthBytesPerSecond, p.SecondsUntilFull = forecastDiskFull(samples, p.Free)
+	}
+
+	// 已卸载或被过滤的挂载点长时间未出现才丢弃历史，短暂消失（如 NFS 抖动、重新挂载）后继续使用
+	expired := now.Add(-diskForecastExpireAfter).Unix()
+	for mountpoint, samples := range f.history {
+		if !active[mountpoint] && (len(samples) == 0 || samples[len(samples)-1].Timestamp < expired) {
+			delete(f.history, mountpoint)
+			changed = true
+		}
+	}
+
+	if changed {
+		f.save()
+	}
+}
+
+// forecastDiskFull 返回增长速率（字节/秒）和预计写满秒数；数据不足或未增长时秒数为 -1
+func forecastDiskFull(samples []diskUsageSample, free uint64) (float64, int64) {
+	if len(samples) < diskForecastMinSamples {
+		return 0, -1
+	}
+	span := samples[len(samples)-1].Timestamp - samples[0].Timestamp
+	if time.Duration(span)*time.Second < diskForecastMinSpan {
+		return 0, -1
+	}
+
+	// 以首个样本为原点，避免大数相乘丢失精度
+	base := samples[0]
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sample := range samples {
+		x := float64(sample.Timestamp - base.Timestamp)
+		y := float64(sample.Used) - float64(base.Used)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, -1
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	if slope <= 0 {
+		return slope, -1
+	}
+	return slope, int64(float64(free) / slope)
+}
+
+func (f *diskForecaster) save() {
+	if f.path == "" {
+		return
+	}
+	data, err := json.Marshal(f.history)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		log.Printf("Failed to create disk forecast state dir: %v", err)
+		return
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		log.Printf("Failed to write disk forecast state: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		log.Printf("Failed to save disk forecast state: %v", err)
+	}
+}
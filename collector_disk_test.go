@@ -0,0 +1,217 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+func TestDiskIORatesMatchIostat(t *testing.T) {
+	prev := disk.IOCountersStat{ReadCount: 100, WriteCount: 200, ReadBytes: 1 << 20, WriteBytes: 2 << 20, ReadTime: 500, WriteTime: 1000, IoTime: 1000, WeightedIO: 2000}
+	current := disk.IOCountersStat{ReadCount: 150, WriteCount: 350, ReadBytes: 11 << 20, WriteBytes: 22 << 20, ReadTime: 1000, WriteTime: 2500, IoTime: 6000, WeightedIO: 22000, IopsInProgress: 3}
+
+	io := diskIORates(prev, current, 10)
+
+	if io.ReadBytesPerSec != 1<<20 || io.WriteBytesPerSec != 2<<20 {
+		t.Fatalf("unexpected throughput: read=%.0f write=%.0f", io.ReadBytesPerSec, io.WriteBytesPerSec)
+	}
+	if io.ReadIOPS != 5 || io.WriteIOPS != 15 {
+		t.Fatalf("unexpected iops: read=%.2f write=%.2f", io.ReadIOPS, io.WriteIOPS)
+	}
+	if io.ReadAwaitMs != 10 || io.WriteAwaitMs != 10 || io.AwaitMs != 10 {
+		t.Fatalf("unexpected await: read=%.2f write=%.2f total=%.2f", io.ReadAwaitMs, io.WriteAwaitMs, io.AwaitMs)
+	}
+	if io.QueueDepth != 2 {
+		t.Fatalf("expected queue depth 2, got %.2f", io.QueueDepth)
+	}
+	if io.UtilPercent != 50 {
+		t.Fatalf("expected util 50%%, got %.2f", io.UtilPercent)
+	}
+	if io.IopsInProgress != 3 {
+		t.Fatalf("expected 3 in-flight IOs, got %d", io.IopsInProgress)
+	}
+}
+
+func TestDiskCollectorIOMapsDeviceMapperToMountpoints(t *testing.T) {
+	collector := NewDiskCollector(DiskConfig{})
+	mountpoints := diskDeviceMountpoints([]disk.PartitionStat{
+		{Device: "/dev/mapper/vg-root", Mountpoint: "/"},
+		{Device: "/dev/sda1", Mountpoint: "/boot"},
+		{Device: "tmpfs", Mountpoint: "/run"},
+	})
+
+	start := time.Unix(1000, 0)
+	first := map[string]disk.IOCountersStat{
+		"dm-0":  {Name: "dm-0", Label: "vg-root", ReadBytes: 0},
+		"sda1":  {Name: "sda1", ReadBytes: 0},
+		"loop0": {Name: "loop0"},
+	}
+	if io := collector.collectIO(first, mountpoints, start); len(io) != 0 {
+		t.Fatalf("expected no rates on first sample, got %#v", io)
+	}
+
+	second := map[string]disk.IOCountersStat{
+		"dm-0":  {Name: "dm-0", Label: "vg-root", ReadBytes: 1000},
+		"sda1":  {Name: "sda1", ReadBytes: 500},
+		"loop0": {Name: "loop0", ReadBytes: 100},
+	}
+	io := collector.collectIO(second, mountpoints, start.Add(10*time.Second))
+	if len(io) != 2 {
+		t.Fatalf("expected dm-0 and sda1 without loop devices, got %#v", io)
+	}
+	if io[0].Device != "dm-0" || len(io[0].Mountpoints) != 1 || io[0].Mountpoints[0] != "/" || io[0].ReadBytesPerSec != 100 {
+		t.Fatalf("unexpected dm-0 metrics: %#v", io[0])
+	}
+	if io[1].Device != "sda1" || len(io[1].Mountpoints) != 1 || io[1].Mountpoints[0] != "/boot" {
+		t.Fatalf("unexpected sda1 metrics: %#v", io[1])
+	}
+}
+
+func TestPartitionMetricsIncludeInodes(t *testing.T) {
+	pm := partitionMetricsFromUsage(
+		disk.PartitionStat{Device: "/dev/sda1", Mountpoint: "/data", Fstype: "ext4"},
+		&disk.UsageStat{Total: 1000, Used: 400, Free: 600, UsedPercent: 40, InodesTotal: 100, InodesUsed: 98, InodesFree: 2, InodesUsedPercent: 98},
+	)
+
+	if pm.UsedPercent != 40 {
+		t.Fatalf("expected used percent 40, got %.2f", pm.UsedPercent)
+	}
+	if pm.InodesTotal != 100 || pm.InodesUsed != 98 || pm.InodesFree != 2 || pm.InodesUsedPercent != 98 {
+		t.Fatalf("unexpected inode metrics: %#v", pm)
+	}
+}
+
+func TestFilterPartitionsWithDefaults(t *testing.T) {
+	config := LoadAgentConfigFromPath(filepath.Join(t.TempDir(), "missing.yaml")).Disk
+	partitions := []disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+		{Device: "/dev/sda1", Mountpoint: "/var/lib/kubelet/pods/abc/volumes/data", Fstype: "ext4"},
+		{Device: "/dev/sdb1", Mountpoint: "/data", Fstype: "xfs"},
+		{Device: "/dev/sdb1", Mountpoint: "/srv/data-bind", Fstype: "xfs"},
+		{Device: "overlay", Mountpoint: "/var/lib/docker/overlay2/abc/merged", Fstype: "overlay"},
+		{Device: "tmpfs", Mountpoint: "/run", Fstype: "tmpfs"},
+		{Device: "/dev/loop3", Mountpoint: "/snap/core/123", Fstype: "squashfs"},
+	}
+
+	filtered := filterPartitions(partitions, config)
+
+	if len(filtered) != 2 {
+		t.Fatalf("expected / and /data, got %#v", filtered)
+	}
+	if filtered[0].Mountpoint != "/" || filtered[1].Mountpoint != "/data" {
+		t.Fatalf("unexpected mountpoints: %q, %q", filtered[0].Mountpoint, filtered[1].Mountpoint)
+	}
+}
+
+func TestFilterPartitionsIncludeLists(t *testing.T) {
+	partitions := []disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+		{Device: "/dev/sdb1", Mountpoint: "/data/mysql", Fstype: "xfs"},
+		{Device: "/dev/sdc1", Mountpoint: "/backup", Fstype: "xfs"},
+	}
+
+	filtered := filterPartitions(partitions, DiskConfig{
+		IncludeMountpoints: []string{"/data/**"},
+		IncludeFstypes:     []string{"xfs"},
+	})
+
+	if len(filtered) != 1 || filtered[0].Mountpoint != "/data/mysql" {
+		t.Fatalf("expected only /data/mysql, got %#v", filtered)
+	}
+}
+
+func TestFilterPartitionsDedupesOnlyBlockDevices(t *testing.T) {
+	partitions := []disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+		{Device: "/dev/sda1", Mountpoint: "/srv/bind", Fstype: "ext4"},
+		{Device: "tmpfs", Mountpoint: "/run", Fstype: "tmpfs"},
+		{Device: "tmpfs", Mountpoint: "/dev/shm", Fstype: "tmpfs"},
+	}
+
+	filtered := filterPartitions(partitions, DiskConfig{DedupeDevices: true})
+
+	var mountpoints []string
+	for _, partition := range filtered {
+		mountpoints = append(mountpoints, partition.Mountpoint)
+	}
+	if len(mountpoints) != 3 || mountpoints[0] != "/" || mountpoints[1] != "/dev/shm" || mountpoints[2] != "/run" {
+		t.Fatalf("expected tmpfs mounts kept separately, got %v", mountpoints)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"/dev/loop*", "/dev/loop12", true},
+		{"/snap/*", "/snap/core/123", false},
+		{"/snap/**", "/snap/core/123", true},
+		{"/var/**/logs", "/var/logs", true},
+		{"/var/**/logs", "/var/app/1/logs", true},
+		{"/mnt/disk[0-9]", "/mnt/disk3", true},
+		{"/mnt/disk[!0-9]", "/mnt/disk3", false},
+		{"tmpfs", "tmpfs", true},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.value); got != tt.want {
+			t.Fatalf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDiskForecasterPredictsTimeUntilFull(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state", "disk-forecast.json")
+	forecaster := newDiskForecaster(statePath, time.Hour)
+	start := time.Unix(100000, 0)
+
+	// 每分钟增长 60MB，即 1MB/s
+	for i := 0; i < 5; i++ {
+		partitions := []PartitionMetrics{{Mountpoint: "/data", Used: uint64(i) * 60 << 20, Free: 600 << 20}}
+		forecaster.observe(partitions, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	// 重启后从状态文件恢复历史
+	restored := newDiskForecaster(statePath, time.Hour)
+	partitions := []PartitionMetrics{{Mountpoint: "/data", Used: 300 << 20, Free: 600 << 20}}
+	restored.observe(partitions, start.Add(5*time.Minute))
+
+	if partitions[0].GrowthBytesPerSecond != 1<<20 {
+		t.Fatalf("expected growth of 1MB/s, got %.2f", partitions[0].GrowthBytesPerSecond)
+	}
+	if partitions[0].SecondsUntilFull != 600 {
+		t.Fatalf("expected 600s until full, got %d", partitions[0].SecondsUntilFull)
+	}
+}
+
+func TestDiskForecasterKeepsHistoryOfBrieflyMissingMounts(t *testing.T) {
+	forecaster := newDiskForecaster("", time.Hour)
+	start := time.Unix(100000, 0)
+	forecaster.observe([]PartitionMetrics{{Mountpoint: "/nfs", Used: 1}}, start)
+
+	// 挂载点短暂消失时保留历史
+	forecaster.observe(nil, start.Add(time.Minute))
+	if len(forecaster.history["/nfs"]) != 1 {
+		t.Fatalf("history should survive a brief absence: %v", forecaster.history)
+	}
+
+	forecaster.observe(nil, start.Add(diskForecastExpireAfter+time.Minute))
+	if _, ok := forecaster.history["/nfs"]; ok {
+		t.Fatalf("history should expire after %s: %v", diskForecastExpireAfter, forecaster.history)
+	}
+}
+
+func TestForecastDiskFullRequiresGrowthAndHistory(t *testing.T) {
+	if _, seconds := forecastDiskFull([]diskUsageSample{{Timestamp: 0, Used: 1}, {Timestamp: 60, Used: 2}}, 100); seconds != -1 {
+		t.Fatalf("expected -1 with too few samples, got %d", seconds)
+	}
+
+	shrinking := []diskUsageSample{{Timestamp: 0, Used: 300}, {Timestamp: 300, Used: 200}, {Timestamp: 600, Used: 100}}
+	if growth, seconds := forecastDiskFull(shrinking, 100); growth >= 0 || seconds != -1 {
+		t.Fatalf("expected negative growth and -1, got %.2f %d", growth, seconds)
+	}
+}
@@ -2,17 +2,68 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 )
 
-type DockerCollector struct{}
+// ContainerRuntime 容器运行时后端，统一输出 DockerContainerInfo 结构
+type ContainerRuntime interface {
+	Name() string
+	ListContainers() ([]DockerContainerInfo, error)
+	Close() error
+}
+
+type DockerCollector struct {
+	config  ContainerConfig
+	runtime ContainerRuntime
+	lastErr string
+}
+
+// containerRuntimeSocket 自动探测时按顺序检查的运行时 socket
+type containerRuntimeSocket struct {
+	runtime string
+	path    string
+}
+
+var defaultContainerRuntimeSockets = []containerRuntimeSocket{
+	{"docker", "/var/run/docker.sock"},
+	{"docker", "/run/docker.sock"},
+	{"podman", "/run/podman/podman.sock"},
+	{"cri", "/run/containerd/containerd.sock"},
+	{"cri", "/run/crio/crio.sock"},
+	{"cri", "/var/run/cri-dockerd.sock"},
+}
 
-func NewDockerCollector() *DockerCollector {
-	return &DockerCollector{}
+// containerRuntimeSockets 默认候选加上当前用户的 rootless podman socket
+func containerRuntimeSockets() []containerRuntimeSocket {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		return defaultContainerRuntimeSockets
+	}
+	candidates := make([]containerRuntimeSocket, 0, len(defaultContainerRuntimeSockets)+1)
+	for _, candidate := range defaultContainerRuntimeSockets {
+		candidates = append(candidates, candidate)
+		if candidate.runtime == "podman" {
+			candidates = append(candidates, containerRuntimeSocket{"podman", filepath.Join(dir, "podman", "podman.sock")})
+		}
+	}
+	return candidates
+}
+
+func NewDockerCollector(config ContainerConfig) *DockerCollector {
+	if config.Runtime == "" {
+		config.Runtime = "auto"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5
+	}
+	return &DockerCollector{config: config}
 }
 
 func (c *DockerCollector) Name() string {
@@ -42,6 +93,9 @@ type DockerContainerInfo struct {
 	NetworkTx     uint64    `json:"network_tx"`
 	BlockRead     uint64    `json:"block_read"`
 	BlockWrite    uint64    `json:"block_write"`
+	Runtime       string    `json:"runtime"`
+	PodName       string    `json:"pod_name,omitempty"`
+	PodNamespace  string    `json:"pod_namespace,omitempty"`
 }
 
 type dockerCPUStats struct {
@@ -54,21 +108,125 @@ type dockerCPUStats struct {
 }
 
 func (c *DockerCollector) Collect() (interface{}, error) {
-	containers, err := c.collectWithDockerCLI()
+	if c.runtime == nil {
+		runtime, err := c.selectRuntime()
+		if err != nil {
+			c.logUnavailable(err)
+			return &DockerMetrics{Containers: []DockerContainerInfo{}, Total: 0}, nil
+		}
+		log.Printf("Using container runtime: %s", runtime.Name())
+		c.runtime = runtime
+	}
+
+	containers, err := c.runtime.ListContainers()
 	if err != nil {
-		log.Printf("Docker collection unavailable: %v", err)
+		c.logUnavailable(fmt.Errorf("%s: %w", c.runtime.Name(), err))
+		if c.config.Runtime == "auto" {
+			// 自动探测模式下运行时可能被替换（如 dockerd 停止），关闭连接后下次重新探测
+			c.runtime.Close()
+			c.runtime = nil
+		}
 		return &DockerMetrics{Containers: []DockerContainerInfo{}, Total: 0}, nil
 	}
+	c.lastErr = ""
 	return &DockerMetrics{Containers: containers, Total: len(containers)}, nil
 }
 
-func (c *DockerCollector) collectWithDockerCLI() ([]DockerContainerInfo, error) {
-	listOutput, err := exec.Command("docker", "ps", "-a", "--format", "{{json .}}").Output()
+// logUnavailable 同一错误只记录一次，避免每个采集周期刷屏
+func (c *DockerCollector) logUnavailable(err error) {
+	if err.Error() == c.lastErr {
+		return
+	}
+	c.lastErr = err.Error()
+	log.Printf("Container collection unavailable: %v", err)
+}
+
+func (c *DockerCollector) selectRuntime() (ContainerRuntime, error) {
+	timeout := time.Duration(c.config.Timeout) * time.Second
+	name, socket := c.config.Runtime, c.config.Socket
+	if name == "auto" {
+		var ok bool
+		name, socket, ok = detectContainerRuntime(containerRuntimeSockets(), socket)
+		if !ok {
+			return nil, fmt.Errorf("no container runtime socket found")
+		}
+	}
+
+	switch name {
+	case "docker":
+		return &dockerCLIRuntime{socket: socket}, nil
+	case "podman":
+		return &podmanCLIRuntime{socket: socket}, nil
+	case "cri":
+		if socket == "" {
+			for _, candidate := range defaultContainerRuntimeSockets {
+				if candidate.runtime == "cri" && socketExists(candidate.path) {
+					socket = candidate.path
+					break
+				}
+			}
+		}
+		if socket == "" {
+			return nil, fmt.Errorf("no CRI socket found")
+		}
+		return newCRIRuntime(socket, timeout)
+	default:
+		return nil, fmt.Errorf("unknown container runtime %q", name)
+	}
+}
+
+// detectContainerRuntime 按候选顺序返回第一个存在的 socket；配置了 socket 时按路径推断运行时
+func detectContainerRuntime(candidates []containerRuntimeSocket, configured string) (string, string, bool) {
+	if configured != "" {
+		for _, candidate := range candidates {
+			if candidate.path == configured {
+				return candidate.runtime, configured, socketExists(configured)
+			}
+		}
+		return "cri", configured, socketExists(configured)
+	}
+	for _, candidate := range candidates {
+		if socketExists(candidate.path) {
+			return candidate.runtime, candidate.path, true
+		}
+	}
+	return "", "", false
+}
+
+func socketExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
+
+// runtimeCommand 构造 CLI 命令，指定了 socket 时通过 hostEnv（DOCKER_HOST、CONTAINER_HOST）传给 CLI
+func runtimeCommand(hostEnv, socket, name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	if socket != "" {
+		cmd.Env = append(os.Environ(), hostEnv+"=unix://"+socket)
+	}
+	return cmd
+}
+
+// dockerCLIRuntime 通过 docker CLI 采集
+type dockerCLIRuntime struct {
+	socket string
+}
+
+func (r *dockerCLIRuntime) Name() string {
+	return "docker"
+}
+
+func (r *dockerCLIRuntime) Close() error {
+	return nil
+}
+
+func (r *dockerCLIRuntime) ListContainers() ([]DockerContainerInfo, error) {
+	listOutput, err := runtimeCommand("DOCKER_HOST", r.socket, "docker", "ps", "-a", "--format", "{{json .}}").Output()
 	if err != nil {
 		return nil, err
 	}
 
-	statsOutput, _ := exec.Command("docker", "stats", "--no-stream", "--format", "{{json .}}").Output()
+	statsOutput, _ := runtimeCommand("DOCKER_HOST", r.socket, "docker", "stats", "--no-stream", "--format", "{{json .}}").Output()
 	statsByID := parseDockerStatsOutput(string(statsOutput))
 
 	lines := strings.Split(strings.TrimSpace(string(listOutput)), "\n")
@@ -82,29 +240,55 @@ func (c *DockerCollector) collectWithDockerCLI() ([]DockerContainerInfo, error)
 		if err := json.Unmarshal([]byte(line), &raw); err != nil {
 			continue
 		}
+		labels := parseDockerLabels(raw.Labels)
 		info := DockerContainerInfo{
-			ContainerID: raw.ID,
-			Name:        raw.Names,
-			Image:       raw.Image,
-			State:       normalizeDockerState(raw.State),
-			Status:      raw.Status,
-			Ports:       raw.Ports,
+			ContainerID:  raw.ID,
+			Name:         raw.Names,
+			Image:        raw.Image,
+			State:        normalizeDockerState(raw.State),
+			Status:       raw.Status,
+			Ports:        raw.Ports,
+			Runtime:      "docker",
+			PodName:      labels[kubernetesPodNameLabel],
+			PodNamespace: labels[kubernetesPodNamespaceLabel],
 		}
 		if stat, ok := statsByID[shortContainerID(raw.ID)]; ok {
-			info.CPUPercent = stat.CPUPercent
-			info.MemoryUsage = stat.MemoryUsage
-			info.MemoryLimit = stat.MemoryLimit
-			info.MemoryPercent = stat.MemoryPercent
-			info.NetworkRx = stat.NetworkRx
-			info.NetworkTx = stat.NetworkTx
-			info.BlockRead = stat.BlockRead
-			info.BlockWrite = stat.BlockWrite
+			mergeContainerStats(&info, stat)
 		}
 		containers = append(containers, info)
 	}
 	return containers, nil
 }
 
+const (
+	kubernetesPodNameLabel       = "io.kubernetes.pod.name"
+	kubernetesPodNamespaceLabel  = "io.kubernetes.pod.namespace"
+	kubernetesContainerNameLabel = "io.kubernetes.container.name"
+)
+
+func mergeContainerStats(info *DockerContainerInfo, stat DockerContainerInfo) {
+	info.CPUPercent = stat.CPUPercent
+	info.MemoryUsage = stat.MemoryUsage
+	info.MemoryLimit = stat.MemoryLimit
+	info.MemoryPercent = stat.MemoryPercent
+	info.NetworkRx = stat.NetworkRx
+	info.NetworkTx = stat.NetworkTx
+	info.BlockRead = stat.BlockRead
+	info.BlockWrite = stat.BlockWrite
+}
+
+// parseDockerLabels 解析 docker ps 输出的 "k1=v1,k2=v2" 标签
+func parseDockerLabels(value string) map[string]string {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		if ok {
+			labels[strings.TrimSpace(key)] = val
+		}
+	}
+	return labels
+}
+
 type dockerPSLine struct {
 	ID     string `json:"ID"`
 	Image  string `json:"Image"`
@@ -112,6 +296,7 @@ type dockerPSLine struct {
 	State  string `json:"State"`
 	Status string `json:"Status"`
 	Ports  string `json:"Ports"`
+	Labels string `json:"Labels"`
 }
 
 type dockerStatsLine struct {
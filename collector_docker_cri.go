@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// criRuntime 通过 CRI gRPC 接口（containerd、CRI-O）采集容器
+type criRuntime struct {
+	socket  string
+	timeout time.Duration
+	conn    *grpc.ClientConn
+	client  runtimeapi.RuntimeServiceClient
+	prevCPU map[string]criCPUSample
+}
+
+type criCPUSample struct {
+	timestamp    int64
+	usageNanoSec uint64
+}
+
+func newCRIRuntime(socket string, timeout time.Duration) (*criRuntime, error) {
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("connect to CRI socket %s: %w", socket, err)
+	}
+	return &criRuntime{
+		socket:  socket,
+		timeout: timeout,
+		conn:    conn,
+		client:  runtimeapi.NewRuntimeServiceClient(conn),
+		prevCPU: make(map[string]criCPUSample),
+	}, nil
+}
+
+func (r *criRuntime) Name() string {
+	return "cri"
+}
+
+func (r *criRuntime) Close() error {
+	return r.conn.Close()
+}
+
+func (r *criRuntime) ListContainers() ([]DockerContainerInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	listResp, err := r.client.ListContainers(ctx, &runtimeapi.ListContainersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	statsByID := make(map[string]*runtimeapi.ContainerStats)
+	if statsResp, err := r.client.ListContainerStats(ctx, &runtimeapi.ListContainerStatsRequest{}); err == nil {
+		for _, stat := range statsResp.GetStats() {
+			statsByID[stat.GetAttributes().GetId()] = stat
+		}
+	}
+
+	containers := make([]DockerContainerInfo, 0, len(listResp.GetContainers()))
+	current := make(map[string]criCPUSample)
+	for _, container := range listResp.GetContainers() {
+		info := criContainerInfo(container)
+		if stat, ok := statsByID[container.GetId()]; ok {
+			applyCRIStats(&info, stat, r.prevCPU[container.GetId()])
+			if usage := stat.GetCpu().GetUsageCoreNanoSeconds(); usage != nil {
+				current[container.GetId()] = criCPUSample{
+					timestamp:    stat.GetCpu().GetTimestamp(),
+					usageNanoSec: usage.GetValue(),
+				}
+			}
+		}
+		containers = append(containers, info)
+	}
+	r.prevCPU = current
+	return containers, nil
+}
+
+func criContainerInfo(container *runtimeapi.Container) DockerContainerInfo {
+	labels := container.GetLabels()
+	name := labels[kubernetesContainerNameLabel]
+	if name == "" {
+		name = container.GetMetadata().GetName()
+	}
+	return DockerContainerInfo{
+		ContainerID:  container.GetId(),
+		Name:         name,
+		Image:        container.GetImage().GetImage(),
+		State:        criContainerState(container.GetState()),
+		Status:       container.GetState().String(),
+		CreatedUnix:  container.GetCreatedAt() / int64(time.Second),
+		RestartCount: int(container.GetMetadata().GetAttempt()),
+		Runtime:      "cri",
+		PodName:      labels[kubernetesPodNameLabel],
+		PodNamespace: labels[kubernetesPodNamespaceLabel],
+	}
+}
+
+// applyCRIStats 优先使用运行时计算好的 usage_nano_cores，缺失时用两次采样的累计值差计算
+func applyCRIStats(info *DockerContainerInfo, stat *runtimeapi.ContainerStats, prev criCPUSample) {
+	cpu := stat.GetCpu()
+	if nanoCores := cpu.GetUsageNanoCores(); nanoCores != nil {
+		info.CPUPercent = float64(nanoCores.GetValue()) / 1e9 * 100
+	} else if usage := cpu.GetUsageCoreNanoSeconds(); usage != nil && prev.timestamp > 0 {
+		elapsed := cpu.GetTimestamp() - prev.timestamp
+		if elapsed > 0 && usage.GetValue() >= prev.usageNanoSec {
+			info.CPUPercent = float64(usage.GetValue()-prev.usageNanoSec) / float64(elapsed) * 100
+		}
+	}
+
+	memory := stat.GetMemory()
+	info.MemoryUsage = memory.GetWorkingSetBytes().GetValue()
+	if available := memory.GetAvailableBytes().GetValue(); available > 0 {
+		info.MemoryLimit = info.MemoryUsage + available
+	}
+	info.MemoryPercent = calculateDockerMemoryPercent(info.MemoryUsage, info.MemoryLimit)
+}
+
+func criContainerState(state runtimeapi.ContainerState) string {
+	return normalizeDockerState(strings.TrimPrefix(state.String(), "CONTAINER_"))
+}
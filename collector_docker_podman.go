@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// podmanCLIRuntime 通过 podman CLI 采集，输出结构与 docker 保持一致
+type podmanCLIRuntime struct {
+	socket string
+}
+
+func (r *podmanCLIRuntime) Name() string {
+	return "podman"
+}
+
+func (r *podmanCLIRuntime) Close() error {
+	return nil
+}
+
+func (r *podmanCLIRuntime) ListContainers() ([]DockerContainerInfo, error) {
+	listOutput, err := runtimeCommand("CONTAINER_HOST", r.socket, "podman", "ps", "-a", "--format", "json").Output()
+	if err != nil {
+		return nil, err
+	}
+	containers, err := parsePodmanPSOutput(listOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	statsOutput, _ := runtimeCommand("CONTAINER_HOST", r.socket, "podman", "stats", "--no-stream", "--format", "json").Output()
+	statsByID := parsePodmanStatsOutput(statsOutput)
+	for i := range containers {
+		if stat, ok := statsByID[shortContainerID(containers[i].ContainerID)]; ok {
+			mergeContainerStats(&containers[i], stat)
+		}
+	}
+	return containers, nil
+}
+
+type podmanPSEntry struct {
+	ID        string            `json:"Id"`
+	Image     string            `json:"Image"`
+	Names     []string          `json:"Names"`
+	State     string            `json:"State"`
+	Status    string            `json:"Status"`
+	Created   int64             `json:"Created"`
+	StartedAt int64             `json:"StartedAt"`
+	Restarts  int               `json:"Restarts"`
+	Labels    map[string]string `json:"Labels"`
+	PodName   string            `json:"PodName"`
+	Ports     []podmanPort      `json:"Ports"`
+}
+
+type podmanPort struct {
+	HostIP        string `json:"host_ip"`
+	HostPort      int    `json:"host_port"`
+	ContainerPort int    `json:"container_port"`
+	Protocol      string `json:"protocol"`
+}
+
+type podmanStatsEntry struct {
+	ID         string `json:"id"`
+	CPUPercent string `json:"cpu_percent"`
+	MemUsage   string `json:"mem_usage"`
+	MemPercent string `json:"mem_percent"`
+	NetIO      string `json:"net_io"`
+	BlockIO    string `json:"block_io"`
+}
+
+func parsePodmanPSOutput(output []byte) ([]DockerContainerInfo, error) {
+	var entries []podmanPSEntry
+	if len(strings.TrimSpace(string(output))) == 0 {
+		return []DockerContainerInfo{}, nil
+	}
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, err
+	}
+
+	containers := make([]DockerContainerInfo, 0, len(entries))
+	for _, entry := range entries {
+		info := DockerContainerInfo{
+			ContainerID:  entry.ID,
+			Name:         strings.Join(entry.Names, ","),
+			Image:        entry.Image,
+			State:        normalizeDockerState(entry.State),
+			Status:       entry.Status,
+			CreatedUnix:  entry.Created,
+			RestartCount: entry.Restarts,
+			Ports:        formatPodmanPorts(entry.Ports),
+			Runtime:      "podman",
+			PodName:      entry.PodName,
+			PodNamespace: entry.Labels[kubernetesPodNamespaceLabel],
+		}
+		if info.PodName == "" {
+			info.PodName = entry.Labels[kubernetesPodNameLabel]
+		}
+		if entry.StartedAt > 0 {
+			info.StartedAt = time.Unix(entry.StartedAt, 0)
+		}
+		containers = append(containers, info)
+	}
+	return containers, nil
+}
+
+func parsePodmanStatsOutput(output []byte) map[string]DockerContainerInfo {
+	result := make(map[string]DockerContainerInfo)
+	var entries []podmanStatsEntry
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return result
+	}
+	for _, entry := range entries {
+		usage, limit := parseDockerUsagePair(entry.MemUsage)
+		rx, tx := parseDockerUsagePair(entry.NetIO)
+		read, write := parseDockerUsagePair(entry.BlockIO)
+		result[shortContainerID(entry.ID)] = DockerContainerInfo{
+			CPUPercent:    parsePercent(entry.CPUPercent),
+			MemoryUsage:   usage,
+			MemoryLimit:   limit,
+			MemoryPercent: parsePercent(entry.MemPercent),
+			NetworkRx:     rx,
+			NetworkTx:     tx,
+			BlockRead:     read,
+			BlockWrite:    write,
+		}
+	}
+	return result
+}
+
+// formatPodmanPorts 转成与 docker ps 相同的 "0.0.0.0:8080->80/tcp" 格式
+func formatPodmanPorts(ports []podmanPort) string {
+	parts := make([]string, 0, len(ports))
+	for _, port := range ports {
+		hostIP := port.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d->%d/%s", hostIP, port.HostPort, port.ContainerPort, protocol))
+	}
+	return strings.Join(parts, ", ")
+}
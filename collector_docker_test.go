@@ -1,6 +1,15 @@
 package main
 
-import "testing"
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
 
 func TestCalculateDockerCPUPercent(t *testing.T) {
 	stats := dockerCPUStats{
@@ -24,8 +33,146 @@ func TestCalculateDockerMemoryPercent(t *testing.T) {
 }
 
 func TestDockerCollectorName(t *testing.T) {
-	collector := NewDockerCollector()
+	collector := NewDockerCollector(ContainerConfig{})
 	if got := collector.Name(); got != "docker" {
 		t.Fatalf("Name() = %q, want docker", got)
 	}
 }
+
+func TestDetectContainerRuntimeUsesFirstExistingSocket(t *testing.T) {
+	dir := t.TempDir()
+	criSocket := filepath.Join(dir, "containerd.sock")
+	listener, err := net.Listen("unix", criSocket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer listener.Close()
+
+	candidates := []containerRuntimeSocket{
+		{"docker", filepath.Join(dir, "docker.sock")},
+		{"cri", criSocket},
+	}
+	runtime, socket, ok := detectContainerRuntime(candidates, "")
+	if !ok || runtime != "cri" || socket != criSocket {
+		t.Fatalf("detectContainerRuntime() = (%q, %q, %v), want cri at %s", runtime, socket, ok, criSocket)
+	}
+
+	if _, _, ok := detectContainerRuntime(candidates[:1], ""); ok {
+		t.Fatal("expected no runtime when no socket exists")
+	}
+}
+
+func TestContainerRuntimeSocketsIncludesRootlessPodman(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	candidates := containerRuntimeSockets()
+	rootless := slices.Index(candidates, containerRuntimeSocket{"podman", "/run/user/1000/podman/podman.sock"})
+	rootful := slices.Index(candidates, containerRuntimeSocket{"podman", "/run/podman/podman.sock"})
+	if rootless != rootful+1 {
+		t.Fatalf("expected rootless podman socket right after the rootful one, got %v", candidates)
+	}
+}
+
+func TestRuntimeCommandPassesSocket(t *testing.T) {
+	cmd := runtimeCommand("CONTAINER_HOST", "/run/user/1000/podman/podman.sock", "podman", "ps")
+	if !slices.Contains(cmd.Env, "CONTAINER_HOST=unix:///run/user/1000/podman/podman.sock") {
+		t.Fatalf("expected CONTAINER_HOST in command env, got %v", cmd.Env)
+	}
+	if cmd := runtimeCommand("DOCKER_HOST", "", "docker", "ps"); cmd.Env != nil {
+		t.Fatalf("expected inherited env without socket, got %v", cmd.Env)
+	}
+}
+
+type failingRuntime struct {
+	closed bool
+}
+
+func (r *failingRuntime) Name() string { return "fake" }
+
+func (r *failingRuntime) ListContainers() ([]DockerContainerInfo, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (r *failingRuntime) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestDockerCollectorClosesFailedAutoRuntime(t *testing.T) {
+	runtime := &failingRuntime{}
+	collector := NewDockerCollector(ContainerConfig{})
+	collector.runtime = runtime
+	if _, err := collector.Collect(); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if !runtime.closed || collector.runtime != nil {
+		t.Fatal("expected failed runtime to be closed and reselected")
+	}
+}
+
+func TestParsePodmanPSOutput(t *testing.T) {
+	output := []byte(`[{"Id":"abcdef1234567890","Image":"docker.io/library/nginx:latest","Names":["web"],"State":"running","Status":"Up 2 hours","Created":1700000000,"StartedAt":1700000100,"Restarts":1,"Labels":{"io.kubernetes.pod.namespace":"default"},"PodName":"web-pod","Ports":[{"host_ip":"","container_port":80,"host_port":8080,"protocol":"tcp"}]}]`)
+
+	containers, err := parsePodmanPSOutput(output)
+	if err != nil {
+		t.Fatalf("parsePodmanPSOutput returned error: %v", err)
+	}
+	if len(containers) != 1 {
+		t.Fatalf("expected 1 container, got %d", len(containers))
+	}
+	container := containers[0]
+	if container.Name != "web" || container.Runtime != "podman" || container.State != "running" {
+		t.Fatalf("unexpected container: %#v", container)
+	}
+	if container.PodName != "web-pod" || container.PodNamespace != "default" {
+		t.Fatalf("unexpected pod: %q/%q", container.PodNamespace, container.PodName)
+	}
+	if container.Ports != "0.0.0.0:8080->80/tcp" {
+		t.Fatalf("unexpected ports: %q", container.Ports)
+	}
+
+	stats := parsePodmanStatsOutput([]byte(`[{"id":"abcdef123456","cpu_percent":"12.50%","mem_usage":"512MiB / 2GiB","mem_percent":"25.00%","net_io":"1kB / 2kB","block_io":"0B / 0B"}]`))
+	stat, ok := stats[shortContainerID(container.ContainerID)]
+	if !ok || stat.CPUPercent != 12.5 || stat.MemoryUsage != 512*1024*1024 {
+		t.Fatalf("unexpected podman stats: %#v", stats)
+	}
+}
+
+func TestCRIContainerInfo(t *testing.T) {
+	container := &runtimeapi.Container{
+		Id:        "c0ffee",
+		Metadata:  &runtimeapi.ContainerMetadata{Name: "app", Attempt: 3},
+		Image:     &runtimeapi.ImageSpec{Image: "registry/app:v1"},
+		State:     runtimeapi.ContainerState_CONTAINER_RUNNING,
+		CreatedAt: 1700000000 * int64(time.Second),
+		Labels: map[string]string{
+			"io.kubernetes.pod.name":      "app-7d9f",
+			"io.kubernetes.pod.namespace": "billing",
+		},
+	}
+
+	info := criContainerInfo(container)
+	if info.Name != "app" || info.State != "running" || info.RestartCount != 3 || info.CreatedUnix != 1700000000 {
+		t.Fatalf("unexpected container info: %#v", info)
+	}
+	if info.PodName != "app-7d9f" || info.PodNamespace != "billing" {
+		t.Fatalf("unexpected pod: %q/%q", info.PodNamespace, info.PodName)
+	}
+
+	stat := &runtimeapi.ContainerStats{
+		Cpu: &runtimeapi.CpuUsage{
+			Timestamp:            int64(2 * time.Second),
+			UsageCoreNanoSeconds: &runtimeapi.UInt64Value{Value: uint64(1500 * time.Millisecond)},
+		},
+		Memory: &runtimeapi.MemoryUsage{
+			WorkingSetBytes: &runtimeapi.UInt64Value{Value: 256},
+			AvailableBytes:  &runtimeapi.UInt64Value{Value: 768},
+		},
+	}
+	applyCRIStats(&info, stat, criCPUSample{timestamp: int64(time.Second), usageNanoSec: uint64(time.Second)})
+	if info.CPUPercent != 50 {
+		t.Fatalf("expected 50%% cpu from counter delta, got %.2f", info.CPUPercent)
+	}
+	if info.MemoryLimit != 1024 || info.MemoryPercent != 25 {
+		t.Fatalf("unexpected memory: limit=%d percent=%.2f", info.MemoryLimit, info.MemoryPercent)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeKernelProcFiles(t *testing.T, root, stat, vmstat string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, "stat"), []byte(stat), 0644); err != nil {
+		t.Fatalf("write stat: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "vmstat"), []byte(vmstat), 0644); err != nil {
+		t.Fatalf("write vmstat: %v", err)
+	}
+}
+
+func TestKernelCollectorComputesRatesBetweenTicks(t *testing.T) {
+	root := t.TempDir()
+	writeKernelProcFiles(t, root,
+		"cpu  1 2 3 4 5 6 7 8 0 0\nintr 1000 10 20 30\nctxt 5000\nbtime 1700000000\nprocesses 200\nprocs_running 2\nprocs_blocked 0\n",
+		"pgfault 10000\npgmajfault 10\npswpin 0\npswpout 0\npgscan_kswapd 0\npgscan_direct 0\npgsteal_kswapd 0\noom_kill 1\n")
+
+	collector := NewKernelCollector(root)
+	start := time.Unix(1000, 0)
+	first, err := collector.collectAt(start)
+	if err != nil {
+		t.Fatalf("first collect: %v", err)
+	}
+	if first.ContextSwitchesPerSec != 0 || first.OOMKillsDelta != 0 {
+		t.Fatalf("expected no rates on first tick, got %#v", first)
+	}
+
+	writeKernelProcFiles(t, root,
+		"cpu  1 2 3 4 5 6 7 8 0 0\nintr 3000 10 20 30\nctxt 15000\nbtime 1700000000\nprocesses 250\nprocs_running 5\nprocs_blocked 3\n",
+		"pgfault 30000\npgmajfault 30\npswpin 100\npswpout 300\npgscan_kswapd 1000\npgscan_direct 500\npgsteal_kswapd 800\noom_kill 3\n")
+
+	metrics, err := collector.collectAt(start.Add(10 * time.Second))
+	if err != nil {
+		t.Fatalf("second collect: %v", err)
+	}
+	checks := map[string][2]float64{
+		"context switches": {metrics.ContextSwitchesPerSec, 1000},
+		"interrupts":       {metrics.InterruptsPerSec, 200},
+		"forks":            {metrics.ForksPerSec, 5},
+		"page faults":      {metrics.PageFaultsPerSec, 2000},
+		"major faults":     {metrics.MajorPageFaultsPerSec, 2},
+		"swap in":          {metrics.SwapInPagesPerSec, 10},
+		"swap out":         {metrics.SwapOutPagesPerSec, 30},
+		"scan kswapd":      {metrics.PageScanKswapdPerSec, 100},
+		"scan direct":      {metrics.PageScanDirectPerSec, 50},
+		"steal":            {metrics.PageStealPerSec, 80},
+	}
+	for name, pair := range checks {
+		if pair[0] != pair[1] {
+			t.Fatalf("%s rate = %.2f, want %.2f", name, pair[0], pair[1])
+		}
+	}
+	if metrics.ProcsRunning != 5 || metrics.ProcsBlocked != 3 {
+		t.Fatalf("unexpected procs: running=%d blocked=%d", metrics.ProcsRunning, metrics.ProcsBlocked)
+	}
+	if metrics.OOMKills != 3 || metrics.OOMKillsDelta != 2 {
+		t.Fatalf("unexpected oom kills: total=%d delta=%d", metrics.OOMKills, metrics.OOMKillsDelta)
+	}
+}
+
+func TestKernelCollectorWithoutProcStat(t *testing.T) {
+	if _, err := NewKernelCollector(t.TempDir()).collectAt(time.Now()); err == nil {
+		t.Fatal("expected error when /proc/stat is missing")
+	}
+}
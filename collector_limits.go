@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LimitsCollector 系统资源上限采集器：conntrack 表、全局文件句柄和进程打开文件数相对 RLIMIT_NOFILE 的使用率
+type LimitsCollector struct {
+	procRoot string
+	topN     int
+	fds      *procFDTable
+	ownFDs   bool // 未与其他收集器共享时每次采集前自行重置
+}
+
+// NewLimitsCollector fds 为 nil 时使用独立的 fd 表
+func NewLimitsCollector(procRoot string, topN int, fds *procFDTable) *LimitsCollector {
+	if procRoot == "" {
+		procRoot = "/proc"
+	}
+	if topN <= 0 {
+		topN = 10
+	}
+	c := &LimitsCollector{procRoot: procRoot, topN: topN, fds: fds}
+	if c.fds == nil {
+		c.fds, c.ownFDs = newProcFDTable(procRoot), true
+	}
+	return c
+}
+
+// procFDTable 每个采集周期只遍历一次 /proc/<pid>/fd，供资源上限、监听端口和进程分组共用
+// Agent 在每个采集周期开始时调用 reset，之后第一次 snapshot 时遍历
+type procFDTable struct {
+	procRoot string
+	mu       sync.Mutex
+	scanned  bool
+	procs    map[int32][]string // PID 到 fd 目录下的条目名，无权限读取的进程不在其中
+}
+
+func newProcFDTable(procRoot string) *procFDTable {
+	if procRoot == "" {
+		procRoot = "/proc"
+	}
+	return &procFDTable{procRoot: procRoot}
+}
+
+func (t *procFDTable) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scanned = false
+	t.procs = nil
+}
+
+// snapshot 返回本周期各进程的 fd 条目，调用方不能修改返回的数据
+func (t *procFDTable) snapshot() map[int32][]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.scanned {
+		return t.procs
+	}
+	t.scanned = true
+	t.procs = make(map[int32][]string)
+	entries, err := os.ReadDir(t.procRoot)
+	if err != nil {
+		return t.procs
+	}
+	for _, entry := range entries {
+		pid, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		fds, err := os.ReadDir(filepath.Join(t.procRoot, entry.Name(), "fd"))
+		if err != nil {
+			continue
+		}
+		names := make([]string, len(fds))
+		for i, fd := range fds {
+			names[i] = fd.Name()
+		}
+		t.procs[int32(pid)] = names
+	}
+	return t.procs
+}
+
+func (c *LimitsCollector) Name() string {
+	return "limits"
+}
+
+func (c *LimitsCollector) Collect() (interface{}, error) {
+	return c.collect()
+}
+
+func (c *LimitsCollector) collect() (*LimitsMetrics, error) {
+	if c.ownFDs {
+		c.fds.reset()
+	}
+	metrics := &LimitsMetrics{Processes: make([]ProcessFDUsage, 0)}
+
+	// 未加载 nf_conntrack 模块时文件不存在，只标记为不支持
+	conntrackDir := filepath.Join(c.procRoot, "sys", "net", "netfilter")
+	count, countErr := readUintFile(filepath.Join(conntrackDir, "nf_conntrack_count"))
+	limit, limitErr := readUintFile(filepath.Join(conntrackDir, "nf_conntrack_max"))
+	if countErr == nil && limitErr == nil {
+		metrics.ConntrackSupported = true
+		metrics.ConntrackCount = count
+		metrics.ConntrackMax = limit
+		if limit > 0 {
+			metrics.ConntrackPercent = float64(count) / float64(limit) * 100
+		}
+	}
+
+	// file-nr: 已分配句柄数、已分配未使用数（2.6 以后恒为0）、上限
+	if data, err := os.ReadFile(filepath.Join(c.procRoot, "sys", "fs", "file-nr")); err == nil {
+		fields := strings.Fields(string(data))
+		if len(fields) == 3 {
+			allocated, _ := strconv.ParseUint(fields[0], 10, 64)
+			unused, _ := strconv.ParseUint(fields[1], 10, 64)
+			metrics.FileHandlesAllocated = counterDelta(unused, allocated)
+			metrics.FileHandlesMax, _ = strconv.ParseUint(fields[2], 10, 64)
+			if metrics.FileHandlesMax > 0 {
+				metrics.FileHandlesPercent = float64(metrics.FileHandlesAllocated) / float64(metrics.FileHandlesMax) * 100
+			}
+		}
+	}
+
+	metrics.Processes = c.topFDProcesses()
+	return metrics, nil
+}
+
+// topFDProcesses 统计每个进程的打开文件数，按占软限制比例取前 N 个
+// 无权限读取 fd 目录的进程跳过
+func (c *LimitsCollector) topFDProcesses() []ProcessFDUsage {
+	usages := make([]ProcessFDUsage, 0)
+	for pid, fds := range c.fds.snapshot() {
+		dir := filepath.Join(c.procRoot, strconv.FormatInt(int64(pid), 10))
+		usage := ProcessFDUsage{
+			PID:     pid,
+			Name:    readSysfsString(filepath.Join(dir, "comm")),
+			OpenFDs: uint64(len(fds)),
+		}
+		usage.SoftLimit, usage.HardLimit, _ = readOpenFilesLimit(filepath.Join(dir, "limits"))
+		if usage.SoftLimit > 0 {
+			usage.Percent = float64(usage.OpenFDs) / float64(usage.SoftLimit) * 100
+		}
+		usages = append(usages, usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Percent != usages[j].Percent {
+			return usages[i].Percent > usages[j].Percent
+		}
+		if usages[i].OpenFDs != usages[j].OpenFDs {
+			return usages[i].OpenFDs > usages[j].OpenFDs
+		}
+		return usages[i].PID < usages[j].PID
+	})
+	if len(usages) > c.topN {
+		usages = usages[:c.topN]
+	}
+	return usages
+}
+
+// readOpenFilesLimit 解析 /proc/<pid>/limits 中的 "Max open files" 行，unlimited 返回0
+func readOpenFilesLimit(path string) (uint64, uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "Max open files") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "Max open files"))
+		if len(fields) < 2 {
+			break
+		}
+		soft, _ := strconv.ParseUint(fields[0], 10, 64)
+		hard, _ := strconv.ParseUint(fields[1], 10, 64)
+		return soft, hard, nil
+	}
+	return 0, 0, fmt.Errorf("max open files not found in %s", path)
+}
+
+func readUintFile(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
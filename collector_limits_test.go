@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func writeProcFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// writeProcFDs 模拟 /proc/<pid>：写入 comm 并创建 n 个打开的文件描述符
+func writeProcFDs(t *testing.T, root, pid, comm string, n int) {
+	t.Helper()
+	writeProcFile(t, filepath.Join(root, pid, "comm"), comm+"\n")
+	fdDir := filepath.Join(root, pid, "fd")
+	if err := os.MkdirAll(fdDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		if err := os.Symlink("/dev/null", filepath.Join(fdDir, strconv.Itoa(i))); err != nil {
+			t.Fatalf("symlink: %v", err)
+		}
+	}
+}
+
+func writeProcLimits(t *testing.T, root, pid, soft, hard string) {
+	t.Helper()
+	content := "Limit                     Soft Limit           Hard Limit           Units     \n" +
+		"Max processes             24001                24001                processes \n" +
+		"Max open files            " + soft + "                 " + hard + "                files     \n"
+	if err := os.WriteFile(filepath.Join(root, pid, "limits"), []byte(content), 0644); err != nil {
+		t.Fatalf("write limits: %v", err)
+	}
+}
+
+func TestLimitsCollectorReportsUtilization(t *testing.T) {
+	root := t.TempDir()
+	writeProcFile(t, filepath.Join(root, "sys", "net", "netfilter", "nf_conntrack_count"), "52000\n")
+	writeProcFile(t, filepath.Join(root, "sys", "net", "netfilter", "nf_conntrack_max"), "65536\n")
+	writeProcFile(t, filepath.Join(root, "sys", "fs", "file-nr"), "2048\t0\t8192\n")
+
+	writeProcFDs(t, root, "100", "nginx", 3)
+	writeProcLimits(t, root, "100", "4", "4096")
+	writeProcFDs(t, root, "200", "postgres", 1)
+	writeProcLimits(t, root, "200", "1024", "4096")
+	writeProcFDs(t, root, "300", "java", 2)
+	writeProcLimits(t, root, "300", "unlimited", "unlimited")
+
+	metrics, err := NewLimitsCollector(root, 2, nil).collect()
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if !metrics.ConntrackSupported || metrics.ConntrackCount != 52000 || metrics.ConntrackMax != 65536 {
+		t.Fatalf("unexpected conntrack: %#v", metrics)
+	}
+	if metrics.FileHandlesAllocated != 2048 || metrics.FileHandlesPercent != 25 {
+		t.Fatalf("unexpected file handles: allocated=%d percent=%.2f", metrics.FileHandlesAllocated, metrics.FileHandlesPercent)
+	}
+	if len(metrics.Processes) != 2 {
+		t.Fatalf("expected top 2 processes, got %#v", metrics.Processes)
+	}
+	top := metrics.Processes[0]
+	if top.PID != 100 || top.Name != "nginx" || top.OpenFDs != 3 || top.SoftLimit != 4 || top.HardLimit != 4096 || top.Percent != 75 {
+		t.Fatalf("unexpected top process: %#v", top)
+	}
+	if metrics.Processes[1].PID != 200 {
+		t.Fatalf("expected postgres second, got %#v", metrics.Processes[1])
+	}
+}
+
+func TestLimitsCollectorWithoutConntrack(t *testing.T) {
+	metrics, err := NewLimitsCollector(t.TempDir(), 10, nil).collect()
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if metrics.ConntrackSupported || metrics.ConntrackPercent != 0 || len(metrics.Processes) != 0 {
+		t.Fatalf("expected empty metrics without conntrack, got %#v", metrics)
+	}
+}
+
+func TestProcFDTableScansOncePerReset(t *testing.T) {
+	root := t.TempDir()
+	writeProcFDs(t, root, "100", "nginx", 2)
+	table := newProcFDTable(root)
+	if got := len(table.snapshot()[100]); got != 2 {
+		t.Fatalf("expected 2 fds, got %d", got)
+	}
+
+	// 同一周期内再次读取沿用第一次的遍历结果
+	writeProcFDs(t, root, "200", "postgres", 1)
+	if _, ok := table.snapshot()[200]; ok {
+		t.Fatal("snapshot should not rescan before reset")
+	}
+	table.reset()
+	if got := len(table.snapshot()[200]); got != 1 {
+		t.Fatalf("expected rescan after reset, got %d fds", got)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListenerCollector 监听端口清单采集器
+// 读取 /proc/net/{tcp,tcp6,udp,udp6} 中的监听套接字，通过共用 fd 表中的 socket inode 找到所属进程，
+// 并与上一次清单比较，产生新增监听和监听消失事件
+type ListenerCollector struct {
+	procRoot         string
+	skipEphemeralUDP bool
+	fds              *procFDTable
+	ownFDs           bool // 未与其他收集器共享时每次采集前自行重置
+	known            map[string]ListeningSocket
+	started          bool
+}
+
+const (
+	tcpStateListen  = 0x0A
+	udpStateUnbound = 0x07 // UDP 未连接的套接字在 /proc/net/udp 中显示为 CLOSE
+)
+
+// NewListenerCollector skipEphemeralUDP 开启时忽略临时端口范围内的 UDP 套接字，fds 为 nil 时使用独立的 fd 表
+func NewListenerCollector(procRoot string, skipEphemeralUDP bool, fds *procFDTable) *ListenerCollector {
+	if procRoot == "" {
+		procRoot = "/proc"
+	}
+	c := &ListenerCollector{procRoot: procRoot, skipEphemeralUDP: skipEphemeralUDP, fds: fds}
+	if c.fds == nil {
+		c.fds, c.ownFDs = newProcFDTable(procRoot), true
+	}
+	return c
+}
+
+func (c *ListenerCollector) Name() string {
+	return "listener"
+}
+
+func (c *ListenerCollector) Collect() (interface{}, error) {
+	return c.collectAt(time.Now())
+}
+
+func (c *ListenerCollector) collectAt(now time.Time) (*ListenerMetrics, error) {
+	if c.ownFDs {
+		c.fds.reset()
+	}
+	sockets := readListeningSockets(c.procRoot, c.skipEphemeralUDP)
+	wanted := make(map[uint64]bool, len(sockets))
+	for _, socket := range sockets {
+		wanted[socket.inode] = true
+	}
+	owners := socketInodeOwners(c.procRoot, c.fds.snapshot(), wanted)
+
+	metrics := &ListenerMetrics{
+		Sockets: make([]ListeningSocket, 0, len(sockets)),
+		Events:  make([]ListenerEvent, 0),
+	}
+	current := make(map[string]ListeningSocket, len(sockets))
+	for _, socket := range sockets {
+		if owner, ok := owners[socket.inode]; ok {
+			socket.PID = owner.pid
+			socket.Process = owner.name
+		}
+		key := socket.key()
+		// SO_REUSEPORT 时同一地址端口有多个套接字，只保留一条
+		if existing, ok := current[key]; ok && existing.PID != 0 {
+			continue
+		}
+		current[key] = socket.ListeningSocket
+	}
+
+	for key, socket := range current {
+		metrics.Sockets = append(metrics.Sockets, socket)
+		// 首次采集只建立基线，避免 Agent 重启时把所有端口报为新增
+		if _, ok := c.known[key]; !ok && c.started {
+			metrics.Events = append(metrics.Events, ListenerEvent{Type: "new", Socket: socket, Timestamp: now.Unix()})
+		}
+	}
+	for key, socket := range c.known {
+		if _, ok := current[key]; !ok {
+			metrics.Events = append(metrics.Events, ListenerEvent{Type: "gone", Socket: socket, Timestamp: now.Unix()})
+		}
+	}
+
+	c.known = current
+	c.started = true
+
+	sort.Slice(metrics.Sockets, func(i, j int) bool {
+		return listeningSocketLess(metrics.Sockets[i], metrics.Sockets[j])
+	})
+	sort.Slice(metrics.Events, func(i, j int) bool {
+		if metrics.Events[i].Type != metrics.Events[j].Type {
+			return metrics.Events[i].Type > metrics.Events[j].Type
+		}
+		return listeningSocketLess(metrics.Events[i].Socket, metrics.Events[j].Socket)
+	})
+	return metrics, nil
+}
+
+type listeningSocketEntry struct {
+	ListeningSocket
+	inode uint64
+}
+
+func (s listeningSocketEntry) key() string {
+	return s.Protocol + "|" + s.Address + "|" + strconv.Itoa(s.Port)
+}
+
+func listeningSocketLess(a, b ListeningSocket) bool {
+	if a.Port != b.Port {
+		return a.Port < b.Port
+	}
+	if a.Protocol != b.Protocol {
+		return a.Protocol < b.Protocol
+	}
+	return a.Address < b.Address
+}
+
+// readListeningSockets TCP 取 LISTEN 状态；UDP 取未连接且已绑定端口的套接字
+// 未 connect 的 UDP 客户端（如 DNS 查询）与监听套接字无法区分，skipEphemeralUDP 开启时忽略临时端口范围内的 UDP 套接字，
+// 默认不忽略，避免漏掉 WireGuard（51820）等监听在该范围内的服务
+func readListeningSockets(procRoot string, skipEphemeralUDP bool) []listeningSocketEntry {
+	ephemeralLow, ephemeralHigh := 1, 0
+	if skipEphemeralUDP {
+		ephemeralLow, ephemeralHigh = readLocalPortRange(procRoot)
+	}
+	var result []listeningSocketEntry
+	for _, protocol := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		sockets, err := parseProcNetSockets(filepath.Join(procRoot, "net", protocol))
+		if err != nil {
+			continue
+		}
+		isTCP := strings.HasPrefix(protocol, "tcp")
+		for _, socket := range sockets {
+			if isTCP && socket.State != tcpStateListen {
+				continue
+			}
+			if !isTCP && (socket.State != udpStateUnbound || socket.RemotePort != 0 || socket.LocalPort == 0) {
+				continue
+			}
+			if !isTCP && socket.LocalPort >= ephemeralLow && socket.LocalPort <= ephemeralHigh {
+				continue
+			}
+			result = append(result, listeningSocketEntry{
+				ListeningSocket: ListeningSocket{
+					Protocol: protocol,
+					Address:  socket.LocalAddr,
+					Port:     socket.LocalPort,
+					UID:      socket.UID,
+				},
+				inode: socket.Inode,
+			})
+		}
+	}
+	return result
+}
+
+// readLocalPortRange 读取 net.ipv4.ip_local_port_range（同样作用于 IPv6），读取失败时使用内核默认值
+func readLocalPortRange(procRoot string) (int, int) {
+	fields := strings.Fields(readSysfsString(filepath.Join(procRoot, "sys", "net", "ipv4", "ip_local_port_range")))
+	if len(fields) == 2 {
+		low, lowErr := strconv.Atoi(fields[0])
+		high, highErr := strconv.Atoi(fields[1])
+		if lowErr == nil && highErr == nil && low <= high {
+			return low, high
+		}
+	}
+	return 32768, 60999
+}
+
+type socketOwner struct {
+	pid  int32
+	name string
+}
+
+// socketInodeOwners 在本周期的 fd 表中查找监听套接字 inode 所属的进程，只 readlink 到全部找到为止
+// 多个进程共享同一套接字时取 PID 最小的；没有权限读取的进程（非 root 运行时）不在 fd 表中，对应端口的 PID 为0
+func socketInodeOwners(procRoot string, fds map[int32][]string, wanted map[uint64]bool) map[uint64]socketOwner {
+	owners := make(map[uint64]socketOwner)
+	pids := make([]int32, 0, len(fds))
+	for pid := range fds {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+
+	for _, pid := range pids {
+		if len(owners) == len(wanted) {
+			break
+		}
+		dir := filepath.Join(procRoot, strconv.FormatInt(int64(pid), 10))
+		var name string
+		for _, fd := range fds[pid] {
+			link, err := os.Readlink(filepath.Join(dir, "fd", fd))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+			if err != nil || !wanted[inode] {
+				continue
+			}
+			if _, ok := owners[inode]; ok {
+				continue
+			}
+			if name == "" {
+				name = readSysfsString(filepath.Join(dir, "comm"))
+			}
+			owners[inode] = socketOwner{pid: pid, name: name}
+		}
+	}
+	return owners
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func writeProcSocketOwner(t *testing.T, root, pid, comm string, inodes ...string) {
+	t.Helper()
+	fdDir := filepath.Join(root, pid, "fd")
+	if err := os.MkdirAll(fdDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, pid, "comm"), []byte(comm+"\n"), 0644); err != nil {
+		t.Fatalf("write comm: %v", err)
+	}
+	for i, inode := range inodes {
+		if err := os.Symlink("socket:["+inode+"]", filepath.Join(fdDir, strconv.Itoa(3+i))); err != nil {
+			t.Fatalf("symlink: %v", err)
+		}
+	}
+}
+
+const listenerProcNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1001 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000  1000        0 1002 1 0000000000000000 20 4 30 10 -1
+`
+
+const listenerProcNetTCP6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000001000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 2001 1 0000000000000000 100 0 0 10 0
+`
+
+func writeProcNet(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, "net")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func TestListenerCollectorInventoryAndEvents(t *testing.T) {
+	root := t.TempDir()
+	udp := "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops\n" +
+		"  10: 00000000:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 3001 2 0000000000000000 0\n" +
+		"  11: 0100007F:D431 0100007F:0035 01 00000000:00000000 00:00000000 00000000  1000        0 3002 2 0000000000000000 0\n"
+	writeProcNet(t, root, map[string]string{"tcp": listenerProcNetTCP, "tcp6": listenerProcNetTCP6, "udp": udp})
+	writeProcSocketOwner(t, root, "100", "nginx", "1001")
+	writeProcSocketOwner(t, root, "200", "systemd-resolve", "3001")
+
+	collector := NewListenerCollector(root, false, nil)
+	start := time.Unix(1000, 0)
+	first, err := collector.collectAt(start)
+	if err != nil {
+		t.Fatalf("first collect: %v", err)
+	}
+	if len(first.Events) != 0 {
+		t.Fatalf("expected baseline without events, got %#v", first.Events)
+	}
+	if len(first.Sockets) != 3 {
+		t.Fatalf("expected tcp :80, tcp6 :22 and udp :53, got %#v", first.Sockets)
+	}
+	byPort := make(map[int]ListeningSocket)
+	for _, socket := range first.Sockets {
+		byPort[socket.Port] = socket
+	}
+	if web := byPort[80]; web.Protocol != "tcp" || web.Address != "0.0.0.0" || web.PID != 100 || web.Process != "nginx" {
+		t.Fatalf("unexpected tcp listener: %#v", web)
+	}
+	if dns := byPort[53]; dns.Protocol != "udp" || dns.PID != 200 || dns.Process != "systemd-resolve" || dns.UID != 101 {
+		t.Fatalf("unexpected udp listener: %#v", dns)
+	}
+	if ssh := byPort[22]; ssh.Protocol != "tcp6" || ssh.Address != "::1" || ssh.PID != 0 {
+		t.Fatalf("expected unowned tcp6 listener, got %#v", ssh)
+	}
+
+	// :22 停止监听，:8443 开始监听
+	tcp := listenerProcNetTCP + "   4: 00000000:20FB 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1005 1 0000000000000000 100 0 0 10 0\n"
+	writeProcNet(t, root, map[string]string{"tcp": tcp, "tcp6": "  sl  local_address remote_address st\n"})
+	second, err := collector.collectAt(start.Add(10 * time.Second))
+	if err != nil {
+		t.Fatalf("second collect: %v", err)
+	}
+	if len(second.Events) != 2 {
+		t.Fatalf("expected 2 events, got %#v", second.Events)
+	}
+	if event := second.Events[0]; event.Type != "new" || event.Socket.Port != 8443 || event.Timestamp != start.Add(10*time.Second).Unix() {
+		t.Fatalf("unexpected new event: %#v", event)
+	}
+	if event := second.Events[1]; event.Type != "gone" || event.Socket.Port != 22 {
+		t.Fatalf("unexpected gone event: %#v", event)
+	}
+}
+
+func TestReadListeningSocketsEphemeralUDPFilter(t *testing.T) {
+	root := t.TempDir()
+	// 未 connect 的 UDP 客户端套接字：状态同样为 CLOSE 且没有对端地址
+	udp := "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops\n" +
+		"  10: 00000000:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 3001 2 0000000000000000 0\n" +
+		"  12: 00000000:D432 00000000:0000 07 00000000:00000000 00:00000000 00000000  1000        0 3003 2 0000000000000000 0\n" +
+		"  13: 00000000:1F90 00000000:0000 07 00000000:00000000 00:00000000 00000000  1000        0 3004 2 0000000000000000 0\n"
+	writeProcNet(t, root, map[string]string{"udp": udp})
+
+	ports := func(skipEphemeral bool) []int {
+		var ports []int
+		for _, socket := range readListeningSockets(root, skipEphemeral) {
+			ports = append(ports, socket.Port)
+		}
+		return ports
+	}
+	// 默认不过滤：临时端口范围内的 UDP 套接字可能是 WireGuard 等真实服务
+	if got := ports(false); len(got) != 3 {
+		t.Fatalf("expected all unconnected udp sockets by default, got %v", got)
+	}
+	if got := ports(true); len(got) != 2 || got[0] != 53 || got[1] != 8080 {
+		t.Fatalf("expected udp :53 and :8080 with the default range, got %v", got)
+	}
+
+	rangeFile := filepath.Join(root, "sys", "net", "ipv4", "ip_local_port_range")
+	if err := os.MkdirAll(filepath.Dir(rangeFile), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(rangeFile, []byte("1024\t9999\n"), 0644); err != nil {
+		t.Fatalf("write range: %v", err)
+	}
+	if got := ports(true); len(got) != 2 || got[0] != 53 || got[1] != 54322 {
+		t.Fatalf("expected configured range to be honored, got %v", got)
+	}
+}
+
+func TestSocketInodeOwnersOnlyResolvesWantedInodes(t *testing.T) {
+	root := t.TempDir()
+	writeProcSocketOwner(t, root, "300", "nginx-worker", "1001", "1002")
+	writeProcSocketOwner(t, root, "100", "nginx", "1001")
+	writeProcSocketOwner(t, root, "200", "curl", "4001")
+
+	table := newProcFDTable(root)
+	owners := socketInodeOwners(root, table.snapshot(), map[uint64]bool{1001: true, 1002: true})
+	// 共享的监听套接字归属 PID 最小的进程，不需要的 inode 不出现在结果中
+	if owner := owners[1001]; owner.pid != 100 || owner.name != "nginx" {
+		t.Fatalf("expected shared socket to resolve to the lowest pid, got %#v", owner)
+	}
+	if owner := owners[1002]; owner.pid != 300 {
+		t.Fatalf("expected inode 1002 owned by pid 300, got %#v", owner)
+	}
+	if _, ok := owners[4001]; ok || len(owners) != 2 {
+		t.Fatalf("expected only wanted inodes, got %#v", owners)
+	}
+}
@@ -6,6 +6,10 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,20 +17,85 @@ import (
 )
 
 // LogCollector 日志收集器
+// 按文件记录 inode 和读取偏移量持续跟踪新增内容，偏移量在日志上报成功后持久化，
+// Agent 重启后从上次位置继续读取；识别 rename 和 copytruncate 两种轮转方式，并读完轮转前文件的剩余内容
+// log_paths 支持目录和通配模式，定期重新展开以发现新文件
 type LogCollector struct {
-	logPaths []string // 要收集的日志文件路径
-	maxLines int      // 每个文件最多收集的行数
+	finder        *logFileFinder
+	rescanEvery   time.Duration
+	lastScan      time.Time
+	logPaths      []string // 当前展开后要收集的日志文件路径
+	maxLines      int      // 每个文件每周期最多读取的行数
+	maxBytes      int64    // 每个文件每周期最多读取的字节数
+	startAt       string   // 首次发现的文件从哪里开始读：end 或 beginning
+	positionsFile string
+	redactor      *Redactor
+	parsers       []*logParser
+	fileParsers   map[string]*logParser // 文件路径到解析器的缓存
+
+	positions map[string]logPosition // 已上报确认的读取位置
+	pending   map[string]logPosition // 本周期读取后的位置，Commit 后生效
+	started   bool
+}
+
+// logPosition 文件的读取位置；inode 变化说明文件已被轮转
+// Head 为文件开头 HeadLen 字节的校验和，copytruncate 后文件在下次采集前又写到原偏移量以上时用它识别截断
+type logPosition struct {
+	Inode   uint64 `json:"inode"`
+	Offset  int64  `json:"offset"`
+	Head    uint32 `json:"head,omitempty"`
+	HeadLen int64  `json:"head_len,omitempty"`
+}
+
+const logHeadBytes = 256
+
+// logBudget 单个文件每周期的读取额度，轮转前文件和当前文件共用
+type logBudget struct {
+	lines int
+	bytes int64
 }
 
 // NewLogCollector 创建日志收集器
-func NewLogCollector(logPaths []string, maxLines int) *LogCollector {
-	if maxLines <= 0 {
-		maxLines = 100 // 默认每个文件最多100行
+func NewLogCollector(logPaths []string, config LogConfig, redactor *Redactor) *LogCollector {
+	c := &LogCollector{
+		finder: &logFileFinder{
+			patterns: logPaths,
+			exclude:  config.ExcludePaths,
+			maxFiles: config.MaxFiles,
+		},
+		rescanEvery:   time.Duration(config.RescanInterval) * time.Second,
+		maxLines:      config.MaxLinesPerCycle,
+		maxBytes:      config.MaxBytesPerCycle,
+		startAt:       config.StartAt,
+		positionsFile: config.PositionsFile,
+		redactor:      redactor,
+		positions:     loadLogPositions(config.PositionsFile),
+		fileParsers:   make(map[string]*logParser),
+	}
+	for i, parserConfig := range config.Parsers {
+		parser, err := newLogParser(parserConfig)
+		if err != nil {
+			log.Printf("Ignoring log parser #%d (%s): %v", i+1, parserConfig.Format, err)
+			continue
+		}
+		c.parsers = append(c.parsers, parser)
+	}
+	if c.maxLines <= 0 {
+		c.maxLines = 1000
+	}
+	if c.maxBytes <= 0 {
+		c.maxBytes = 1 << 20
+	}
+	if c.startAt != "beginning" {
+		c.startAt = "end"
 	}
-	return &LogCollector{
-		logPaths: logPaths,
-		maxLines: maxLines,
+	if c.finder.maxFiles <= 0 {
+		c.finder.maxFiles = 100
 	}
+	if c.rescanEvery <= 0 {
+		c.rescanEvery = 5 * time.Minute
+	}
+	return c
 }
 
 // Name 返回采集器名称
@@ -34,17 +103,55 @@ func (c *LogCollector) Name() string {
 	return "log"
 }
 
-// Collect 采集日志（只采集ERROR和WARN级别）
+// Collect 采集上次确认位置之后的新增日志（只返回ERROR和WARN级别）
+// 上报失败时不调用 Commit，下个周期会重新读取同一段内容
 func (c *LogCollector) Collect() (interface{}, error) {
+	return c.collectAt(time.Now())
+}
+
+func (c *LogCollector) collectAt(now time.Time) (*LogMetrics, error) {
 	var allLogs []LogEntry
 
+	if c.lastScan.IsZero() || now.Sub(c.lastScan) >= c.rescanEvery || c.rotatedSinceScan() {
+		c.logPaths = c.finder.discover()
+		c.lastScan = now
+	}
+
+	// 只保留当前仍在跟踪的文件位置和解析器，已删除的按日期命名的旧文件不再占用位置文件
+	pending := make(map[string]logPosition, len(c.logPaths))
+	fileParsers := make(map[string]*logParser, len(c.logPaths))
+	for _, logPath := range c.logPaths {
+		if pos, ok := c.positions[logPath]; ok {
+			pending[logPath] = pos
+		}
+		if parser, ok := c.fileParsers[logPath]; ok {
+			fileParsers[logPath] = parser
+		}
+	}
+	c.fileParsers = fileParsers
 	for _, logPath := range c.logPaths {
-		logs, err := c.collectFromFile(logPath)
+		pos, known := c.positions[logPath]
+		if !known {
+			if info, err := os.Stat(logPath); err == nil {
+				if inherited, ok := c.positionByInode(fileInode(info)); ok {
+					// 轮转改名后仍匹配通配模式的文件（如 app-20240101.log），接着原文件的位置读
+					pos, known = inherited, true
+				} else if !c.started && c.startAt == "end" {
+					// Agent 首次运行时不回放已有内容，之后新出现的文件从头读取
+					pos, known = logPosition{Inode: fileInode(info), Offset: info.Size()}, true
+					c.positions[logPath] = pos
+				}
+			}
+		}
+		logs, next, err := c.collectFromFile(logPath, pos, known)
 		if err != nil {
 			continue // 跳过无法读取的文件
 		}
+		pending[logPath] = next
 		allLogs = append(allLogs, logs...)
 	}
+	c.pending = pending
+	c.started = true
 
 	// 只返回ERROR和WARN级别的日志，减少数据量
 	var filteredLogs []LogEntry
@@ -60,69 +167,280 @@ func (c *LogCollector) Collect() (interface{}, error) {
 	}, nil
 }
 
-// collectFromFile 从文件收集日志
-func (c *LogCollector) collectFromFile(filePath string) ([]LogEntry, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
+// Commit 确认上一次 Collect 的读取位置并写入位置文件
+func (c *LogCollector) Commit() {
+	if c.pending == nil {
+		return
 	}
-	defer file.Close()
+	c.positions = c.pending
+	c.pending = nil
+	c.savePositions()
+}
+
+// rotatedSinceScan 有文件被改名轮转时立即重新展开，轮转出的文件若仍匹配通配模式，
+// 要在原文件位置被新 inode 覆盖之前接手，否则会被当作新文件从头读取
+func (c *LogCollector) rotatedSinceScan() bool {
+	for _, logPath := range c.logPaths {
+		pos, ok := c.positions[logPath]
+		if !ok || pos.Inode == 0 {
+			continue
+		}
+		if info, err := os.Stat(logPath); err == nil {
+			if inode := fileInode(info); inode != 0 && inode != pos.Inode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// positionByInode 查找同一 inode 已记录的位置，inode 未知时不匹配
+func (c *LogCollector) positionByInode(inode uint64) (logPosition, bool) {
+	if inode == 0 {
+		return logPosition{}, false
+	}
+	for _, pos := range c.positions {
+		if pos.Inode == inode {
+			return pos, true
+		}
+	}
+	return logPosition{}, false
+}
 
+func (c *LogCollector) tracking(path string) bool {
+	for _, logPath := range c.logPaths {
+		if logPath == path {
+			return true
+		}
+	}
+	return false
+}
+
+// collectFromFile 从上次位置读取文件新增内容，返回新的读取位置
+func (c *LogCollector) collectFromFile(filePath string, pos logPosition, known bool) ([]LogEntry, logPosition, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, pos, err
+	}
+	inode := fileInode(info)
+	budget := &logBudget{lines: c.maxLines, bytes: c.maxBytes}
 	var entries []LogEntry
-	scanner := bufio.NewScanner(file)
-	lineCount := 0
 
-	// 读取文件末尾的N行
-	lines := []string{}
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-		if len(lines) > c.maxLines {
-			lines = lines[1:] // 保持只保留最后N行
+	switch {
+	case known && pos.Inode != 0 && inode != 0 && inode != pos.Inode:
+		// rename 轮转：原文件被改名（如 app.log.1），按 inode 找到后读完剩余内容
+		rotated, rotatedInfo := findRotatedLog(filePath, func(candidate os.FileInfo) bool {
+			return fileInode(candidate) == pos.Inode
+		})
+		// 轮转出的文件本身也在跟踪列表中时由它自己继续读取
+		if rotated != "" && !c.tracking(rotated) {
+			logs, offset := c.readFrom(rotated, filePath, pos.Offset, budget)
+			entries = append(entries, logs...)
+			if offset < rotatedInfo.Size() {
+				// 额度用完，下个周期继续读轮转前的文件
+				return entries, logPosition{Inode: pos.Inode, Offset: offset}, nil
+			}
+		}
+		pos = logPosition{Inode: inode}
+	case info.Size() < pos.Offset || !pos.headMatches(filePath):
+		// copytruncate 轮转：内容已复制到轮转文件后原文件被截断，从复制出的文件读完剩余部分
+		dir := filepath.Dir(filePath)
+		rotated, rotatedInfo := findRotatedLog(filePath, func(candidate os.FileInfo) bool {
+			if (inode != 0 && fileInode(candidate) == inode) || candidate.Size() < pos.Offset {
+				return false
+			}
+			return pos.headMatches(filepath.Join(dir, candidate.Name()))
+		})
+		if rotated != "" {
+			logs, offset := c.readFrom(rotated, filePath, pos.Offset, budget)
+			entries = append(entries, logs...)
+			if offset < rotatedInfo.Size() {
+				// 额度用完，保留原来的开头校验和，下个周期按它找到复制出的文件继续读
+				pos.Offset = offset
+				return entries, pos, nil
+			}
 		}
+		pos = logPosition{}
+	}
+
+	pos.Inode = inode
+	logs, offset := c.readFrom(filePath, filePath, pos.Offset, budget)
+	pos.Offset = offset
+	if pos.HeadLen < logHeadBytes && pos.Offset > pos.HeadLen {
+		pos.Head, pos.HeadLen = fileHead(filePath, min(pos.Offset, logHeadBytes))
 	}
+	return append(entries, logs...), pos, nil
+}
 
-	// 解析日志行
-	for _, line := range lines {
-		if lineCount >= c.maxLines {
+// headMatches 文件开头与记录的校验和一致；没有记录时视为一致
+func (p logPosition) headMatches(filePath string) bool {
+	if p.HeadLen == 0 {
+		return true
+	}
+	head, n := fileHead(filePath, p.HeadLen)
+	return n == p.HeadLen && head == p.Head
+}
+
+// fileHead 计算文件开头 n 字节的校验和，返回实际读取的长度
+func fileHead(filePath string, n int64) (uint32, int64) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+	buf := make([]byte, n)
+	read, _ := io.ReadFull(file, buf)
+	return crc32.ChecksumIEEE(buf[:read]), int64(read)
+}
+
+// readFrom 从 offset 开始读取完整的行，返回解析后的日志和读到的位置
+// 末尾未写完的半行留到下个周期；单行超过整个周期的字节额度时截断读取，避免一直卡住，
+// 额度已被轮转文件用掉一部分时不截断，留到下个周期用完整额度读取
+func (c *LogCollector) readFrom(filePath, source string, offset int64, budget *logBudget) ([]LogEntry, int64) {
+	if budget.lines <= 0 || budget.bytes <= 0 {
+		return nil, offset
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, offset
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset
+	}
+
+	var entries []LogEntry
+	limit := budget.bytes
+	reader := bufio.NewReader(io.LimitReader(file, limit))
+	var consumed int64
+	for budget.lines > 0 {
+		line, err := reader.ReadString('\n')
+		if err != nil && (line == "" || consumed > 0 || int64(len(line)) < limit || limit < c.maxBytes) {
 			break
 		}
+		consumed += int64(len(line))
+		budget.bytes -= int64(len(line))
+		budget.lines--
 
-		entry := c.parseLogLine(filePath, line)
+		entry := c.parseLogLine(source, strings.TrimRight(line, "\r\n"))
 		if entry != nil {
 			entries = append(entries, *entry)
-			lineCount++
+		}
+		if err != nil {
+			break
 		}
 	}
+	return entries, offset + consumed
+}
 
-	return entries, scanner.Err()
+// findRotatedLog 在同目录下查找轮转出的文件（app.log.1、app.log-20240101 等），
+// 压缩文件不参与；多个候选时取最近修改的
+func findRotatedLog(filePath string, match func(os.FileInfo) bool) (string, os.FileInfo) {
+	dir, base := filepath.Split(filePath)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil
+	}
+	var found string
+	var foundInfo os.FileInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == base || !(strings.HasPrefix(name, base+".") || strings.HasPrefix(name, base+"-")) {
+			continue
+		}
+		switch filepath.Ext(name) {
+		case ".gz", ".bz2", ".xz", ".zst", ".zip":
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || !match(info) {
+			continue
+		}
+		if foundInfo == nil || info.ModTime().After(foundInfo.ModTime()) {
+			found, foundInfo = filepath.Join(dir, name), info
+		}
+	}
+	return found, foundInfo
+}
+
+func loadLogPositions(path string) map[string]logPosition {
+	positions := make(map[string]logPosition)
+	if path == "" {
+		return positions
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return positions
+	}
+	if err := json.Unmarshal(data, &positions); err != nil {
+		log.Printf("Ignoring corrupt log positions file %s: %v", path, err)
+		return make(map[string]logPosition)
+	}
+	return positions
+}
+
+func (c *LogCollector) savePositions() {
+	if c.positionsFile == "" {
+		return
+	}
+	data, err := json.Marshal(c.positions)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.positionsFile), 0755); err != nil {
+		log.Printf("Failed to create log positions dir: %v", err)
+		return
+	}
+	tmp := c.positionsFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		log.Printf("Failed to write log positions: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, c.positionsFile); err != nil {
+		log.Printf("Failed to save log positions: %v", err)
+	}
+}
+
+// parserFor 返回文件适用的解析器，没有配置时按纯文本处理
+func (c *LogCollector) parserFor(source string) *logParser {
+	if parser, ok := c.fileParsers[source]; ok {
+		return parser
+	}
+	parser := plainLogParser
+	for _, candidate := range c.parsers {
+		if candidate.matches(source) {
+			parser = candidate
+			break
+		}
+	}
+	c.fileParsers[source] = parser
+	return parser
 }
 
-// parseLogLine 解析日志行
+// parseLogLine 解析日志行，时间戳取自日志内容，无法解析时使用采集时间
+// 结构化格式提取的字段放入 Tags
 func (c *LogCollector) parseLogLine(source, line string) *LogEntry {
 	line = strings.TrimSpace(line)
 	if line == "" {
 		return nil
 	}
 
-	// 简单的日志级别检测
-	level := "INFO"
-	upperLine := strings.ToUpper(line)
-	if strings.Contains(upperLine, "ERROR") || strings.Contains(upperLine, "FATAL") {
-		level = "ERROR"
-	} else if strings.Contains(upperLine, "WARN") || strings.Contains(upperLine, "WARNING") {
-		level = "WARN"
-	} else if strings.Contains(upperLine, "DEBUG") {
-		level = "DEBUG"
+	parsed := c.parserFor(source).parse(line, time.Now())
+	tags := make(map[string]string, len(parsed.fields)+1)
+	for name, value := range parsed.fields {
+		tags[name] = c.redactor.RedactField(name, value)
 	}
+	tags["file"] = source
 
 	return &LogEntry{
 		Source:    filepath.Base(source),
-		Level:     level,
-		Message:   line,
-		Timestamp: time.Now().Unix(),
-		Tags: map[string]string{
-			"file": source,
-		},
+		Level:     parsed.level,
+		Message:   c.redactor.Redact(parsed.message),
+		Timestamp: parsed.timestamp.Unix(),
+		Tags:      tags,
 	}
 }
 
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// logFileFinder 把 log_paths 中的文件、目录和通配模式展开为具体文件
+// 目录只取其中的普通文件，递归需要写成 dir/**；* 和 ? 不跨目录，** 匹配任意多级目录
+type logFileFinder struct {
+	patterns []string
+	exclude  []string
+	maxFiles int
+}
+
+// discover 展开所有模式；不含通配符的文件路径即使暂不存在也保留，文件创建后即可开始读取
+// 匹配到的文件超过 maxFiles 时保留最近修改的，按日期命名的新文件优先
+func (f *logFileFinder) discover() []string {
+	seen := make(map[string]bool)
+	var literal, matched []string
+	for _, pattern := range f.patterns {
+		var files []string
+		if hasGlobMeta(pattern) {
+			files = expandLogGlob(pattern)
+		} else if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			files = listLogDir(pattern)
+		} else {
+			if !seen[pattern] && !f.excluded(pattern) {
+				seen[pattern] = true
+				literal = append(literal, pattern)
+			}
+			continue
+		}
+		for _, file := range files {
+			if !seen[file] && !f.excluded(file) {
+				seen[file] = true
+				matched = append(matched, file)
+			}
+		}
+	}
+
+	files := append(literal, matched...)
+	if len(files) > f.maxFiles {
+		log.Printf("log_paths matched %d files, only tailing the %d most recently modified", len(files), f.maxFiles)
+		files = newestFiles(files, f.maxFiles)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func (f *logFileFinder) excluded(path string) bool {
+	return !matchIncludeExclude(filepath.ToSlash(path), nil, f.exclude)
+}
+
+func hasGlobMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// expandLogGlob 不含 ** 时直接用 filepath.Glob；含 ** 时从第一个通配符之前的目录开始遍历
+// 遍历得到的路径已去掉 ./ 等前缀，模式也先规范化，否则 ./logs/** 这类相对路径匹配不上
+func expandLogGlob(pattern string) []string {
+	pattern = filepath.Clean(pattern)
+	if !strings.Contains(pattern, "**") {
+		paths, _ := filepath.Glob(pattern)
+		files := paths[:0]
+		for _, path := range paths {
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				files = append(files, path)
+			}
+		}
+		return files
+	}
+
+	root := pattern[:strings.IndexAny(pattern, "*?[")]
+	root = root[:strings.LastIndexAny(root, `/\`)+1]
+	if root == "" {
+		root = "."
+	}
+	slashPattern := filepath.ToSlash(pattern)
+	var files []string
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // 跳过无权限的目录
+		}
+		if entry.Type().IsRegular() && matchGlob(slashPattern, filepath.ToSlash(path)) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+func listLogDir(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files
+}
+
+func newestFiles(files []string, limit int) []string {
+	modTimes := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return modTimes[files[i]].After(modTimes[files[j]])
+	})
+	return files[:limit]
+}
@@ -0,0 +1,8 @@
+package main
+
+import "os"
+
+// fileInode Windows 下没有 inode，返回0，轮转只能通过文件变小识别
+func fileInode(info os.FileInfo) uint64 {
+	return 0
+}
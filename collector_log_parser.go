@@ -0,0 +1,421 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logParser 按文件配置的格式解析日志行，提取级别、消息、时间戳和其余字段
+// 解析失败（如 JSON 日志中混入的纯文本行）时按 plain 处理，不丢弃该行
+type logParser struct {
+	paths        []string
+	format       string
+	pattern      *regexp.Regexp
+	messageField string
+	levelField   string
+	timeField    string
+	timeLayout   string
+	location     *time.Location
+}
+
+type parsedLogLine struct {
+	level     string
+	message   string
+	timestamp time.Time
+	fields    map[string]string
+}
+
+var plainLogParser = &logParser{format: "plain", location: time.Local}
+
+var (
+	// combined/common 访问日志，nginx 与 apache 相同
+	accessLogPattern = regexp.MustCompile(`^(?P<remote_addr>\S+) \S+ (?P<remote_user>\S+) \[(?P<time>[^\]]+)\] "(?P<method>[A-Z]+) (?P<path>\S+)(?: (?P<protocol>[^"]+))?" (?P<status>\d{3}) (?P<body_bytes_sent>\d+|-)(?: "(?P<referer>[^"]*)" "(?P<user_agent>[^"]*)")?`)
+	// 2024/01/02 15:04:05 [error] 123#0: *1 message
+	nginxErrorPattern = regexp.MustCompile(`^(?P<time>\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}) \[(?P<level>\w+)\] (?P<pid>\d+)#(?P<tid>\d+): (?:\*(?P<connection>\d+) )?(?P<message>.*)$`)
+	// [Tue Jan 02 15:04:05.123456 2024] [core:error] [pid 123] [client 1.2.3.4:5678] message
+	apacheErrorPattern = regexp.MustCompile(`^\[(?P<time>[^\]]+)\] \[(?:(?P<module>[^:\]]+):)?(?P<level>[^\]]+)\](?: \[pid (?P<pid>\d+)(?::tid \d+)?\])?(?: \[client (?P<client>[^\]]+)\])? (?P<message>.*)$`)
+	// <34>1 2024-01-02T15:04:05.000Z host app 123 ID47 - message
+	syslog5424Pattern = regexp.MustCompile(`^<(?P<pri>\d{1,3})>1 (?P<time>\S+) (?P<host>\S+) (?P<app>\S+) (?P<pid>\S+) (?P<msgid>\S+) (?:-|\[.*?\]) ?(?P<message>.*)$`)
+	// <34>Jan  2 15:04:05 host app[123]: message
+	syslog3164Pattern = regexp.MustCompile(`^(?:<(?P<pri>\d{1,3})>)?(?P<time>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (?P<host>\S+) (?P<app>[^\s:\[]+)(?:\[(?P<pid>\d+)\])?: (?P<message>.*)$`)
+
+	// 纯文本日志只认大写的级别单词，或 level=xxx、[xxx] 这类明确的写法，避免 "no errors" 被识别为 ERROR
+	plainLevelWordPattern  = regexp.MustCompile(`\b(FATAL|PANIC|CRITICAL|CRIT|ERROR|ERR|WARNING|WARN|NOTICE|INFO|DEBUG|TRACE)\b`)
+	plainLevelFieldPattern = regexp.MustCompile(`(?i)(?:\b(?:level|lvl|severity)\s*[=:]\s*"?|\[|<)(fatal|panic|critical|crit|error|err|warning|warn|notice|info|debug|trace)\b`)
+
+	defaultMessageFields = []string{"message", "msg"}
+	defaultLevelFields   = []string{"level", "lvl", "severity", "log.level"}
+	defaultTimeFields    = []string{"time", "timestamp", "ts", "@timestamp"}
+
+	// 未配置 time_layout 时依次尝试；不含时区的按配置的 timezone 解析
+	defaultTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+		"2006/01/02 15:04:05",
+		"02/Jan/2006:15:04:05 -0700",
+		"Mon Jan _2 15:04:05 2006",
+		"Jan _2 15:04:05",
+		time.RFC1123Z,
+		time.RFC1123,
+	}
+)
+
+func newLogParser(config LogParserConfig) (*logParser, error) {
+	p := &logParser{
+		paths:        config.Paths,
+		format:       strings.ToLower(config.Format),
+		messageField: config.MessageField,
+		levelField:   config.LevelField,
+		timeField:    config.TimeField,
+		timeLayout:   config.TimeLayout,
+		location:     time.Local,
+	}
+	if len(p.paths) == 0 {
+		return nil, fmt.Errorf("paths is required")
+	}
+	switch p.format {
+	case "", "plain":
+		p.format = "plain"
+	case "json", "logfmt", "nginx", "apache", "syslog":
+	case "regex":
+		if config.Pattern == "" {
+			return nil, fmt.Errorf("pattern is required for regex format")
+		}
+		re, err := regexp.Compile(config.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		p.pattern = re
+	default:
+		return nil, fmt.Errorf("unknown format %q", config.Format)
+	}
+	if config.Timezone != "" {
+		location, err := time.LoadLocation(config.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		p.location = location
+	}
+	return p, nil
+}
+
+func (p *logParser) matches(path string) bool {
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, pattern := range p.paths {
+		// 发现的文件路径已规范化，./logs/*.json 这类相对模式也要先规范化才能匹配
+		pattern = filepath.ToSlash(filepath.Clean(pattern))
+		if pattern == path || matchGlob(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *logParser) parse(line string, now time.Time) parsedLogLine {
+	fields := p.extract(line)
+	if fields == nil {
+		return parsedLogLine{level: detectLogLevel(line), message: line, timestamp: now}
+	}
+
+	parsed := parsedLogLine{fields: fields, timestamp: now}
+	parsed.message = takeLogField(fields, p.messageField, defaultMessageFields)
+	if parsed.message == "" {
+		parsed.message = line
+	}
+
+	if pri, ok := fields["pri"]; ok && p.format == "syslog" {
+		delete(fields, "pri")
+		if value, err := strconv.Atoi(pri); err == nil {
+			fields["facility"] = strconv.Itoa(value / 8)
+			parsed.level = syslogSeverityLevel(value % 8)
+		}
+	}
+	if level := takeLogField(fields, p.levelField, defaultLevelFields); level != "" {
+		parsed.level = normalizeLogLevel(level)
+	}
+	if parsed.level == "" {
+		// 访问日志没有级别，按 HTTP 状态码归类
+		if status, err := strconv.Atoi(fields["status"]); err == nil {
+			parsed.level = httpStatusLevel(status)
+		} else {
+			parsed.level = detectLogLevel(parsed.message)
+		}
+	}
+
+	if raw := takeLogField(fields, p.timeField, defaultTimeFields); raw != "" {
+		if ts, ok := parseLogTime(raw, p.timeLayout, p.location, now); ok {
+			parsed.timestamp = ts
+		}
+	}
+	return parsed
+}
+
+// extract 按格式提取字段，不匹配时返回 nil
+func (p *logParser) extract(line string) map[string]string {
+	switch p.format {
+	case "json":
+		return parseJSONLogLine(line)
+	case "logfmt":
+		return parseLogfmt(line)
+	case "regex":
+		return namedGroups(p.pattern, line)
+	case "nginx":
+		if fields := namedGroups(accessLogPattern, line); fields != nil {
+			return fields
+		}
+		return namedGroups(nginxErrorPattern, line)
+	case "apache":
+		if fields := namedGroups(accessLogPattern, line); fields != nil {
+			return fields
+		}
+		return namedGroups(apacheErrorPattern, line)
+	case "syslog":
+		if fields := namedGroups(syslog5424Pattern, line); fields != nil {
+			return fields
+		}
+		return namedGroups(syslog3164Pattern, line)
+	}
+	return nil
+}
+
+// takeLogField 取出并删除字段；配置了字段名时只用配置的，否则按默认候选依次查找
+func takeLogField(fields map[string]string, configured string, defaults []string) string {
+	names := defaults
+	if configured != "" {
+		names = []string{configured}
+	}
+	for _, name := range names {
+		if value, ok := fields[name]; ok {
+			delete(fields, name)
+			return value
+		}
+	}
+	return ""
+}
+
+// namedGroups 命名分组成为字段，未参与匹配或值为 "-" 的分组忽略
+func namedGroups(re *regexp.Regexp, line string) map[string]string {
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	fields := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if i == 0 || name == "" || match[i] == "" || match[i] == "-" {
+			continue
+		}
+		fields[name] = match[i]
+	}
+	return fields
+}
+
+// parseJSONLogLine 嵌套对象展开为 a.b 形式的字段，数组保留为 JSON 文本
+func parseJSONLogLine(line string) map[string]string {
+	if !strings.HasPrefix(line, "{") {
+		return nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil
+	}
+	fields := make(map[string]string)
+	flattenJSON("", object, fields)
+	return fields
+}
+
+func flattenJSON(prefix string, object map[string]interface{}, fields map[string]string) {
+	for key, value := range object {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case nil:
+		case string:
+			fields[key] = v
+		case map[string]interface{}:
+			flattenJSON(key, v, fields)
+		case []interface{}:
+			data, _ := json.Marshal(v)
+			fields[key] = string(data)
+		default:
+			fields[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// parseLogfmt 解析 key=value 序列，值可用双引号包裹；没有任何 key=value 时返回 nil
+func parseLogfmt(line string) map[string]string {
+	fields := make(map[string]string)
+	for i := 0; i < len(line); {
+		for i < len(line) && line[i] == ' ' {
+			i++
+		}
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' {
+			i++
+		}
+		key := line[start:i]
+		if i >= len(line) || line[i] != '=' {
+			if key != "" {
+				fields[key] = "true"
+			}
+			continue
+		}
+		i++
+		var value string
+		if i < len(line) && line[i] == '"' {
+			var buf bytes.Buffer
+			i++
+			for i < len(line) && line[i] != '"' {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+				}
+				buf.WriteByte(line[i])
+				i++
+			}
+			i++
+			value = buf.String()
+		} else {
+			start := i
+			for i < len(line) && line[i] != ' ' {
+				i++
+			}
+			value = line[start:i]
+		}
+		if key != "" {
+			fields[key] = value
+		}
+	}
+	for _, value := range fields {
+		if value != "true" {
+			return fields
+		}
+	}
+	return nil
+}
+
+// parseLogTime 按配置的 layout 解析；layout 可为 unix、unix_ms 或 Go 时间格式，未配置时依次尝试常见格式
+// 不带年份的 syslog 时间取当前年份，超过当前时间一天以上说明是去年的日志
+func parseLogTime(raw, layout string, location *time.Location, now time.Time) (time.Time, bool) {
+	switch layout {
+	case "unix", "unix_ms":
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		if layout == "unix_ms" {
+			return time.UnixMilli(int64(value)), true
+		}
+		return time.Unix(0, int64(value*float64(time.Second))), true
+	case "":
+		if value, err := strconv.ParseFloat(raw, 64); err == nil {
+			return unixTimeGuess(value), true
+		}
+		for _, candidate := range defaultTimeLayouts {
+			if ts, ok := parseLogTime(raw, candidate, location, now); ok {
+				return ts, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	ts, err := time.ParseInLocation(layout, raw, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if ts.Year() == 0 {
+		ts = ts.AddDate(now.In(location).Year(), 0, 0)
+		if ts.After(now.Add(24 * time.Hour)) {
+			ts = ts.AddDate(-1, 0, 0)
+		}
+	}
+	return ts, true
+}
+
+// unixTimeGuess 按数量级区分秒、毫秒、微秒和纳秒
+func unixTimeGuess(value float64) time.Time {
+	switch {
+	case value < 1e11:
+		return time.Unix(0, int64(value*float64(time.Second)))
+	case value < 1e14:
+		return time.UnixMilli(int64(value))
+	case value < 1e17:
+		return time.UnixMicro(int64(value))
+	default:
+		return time.Unix(0, int64(value))
+	}
+}
+
+// detectLogLevel 纯文本日志的级别识别，未识别时为 INFO
+func detectLogLevel(text string) string {
+	if match := plainLevelFieldPattern.FindStringSubmatch(text); match != nil {
+		return normalizeLogLevel(match[1])
+	}
+	if match := plainLevelWordPattern.FindStringSubmatch(text); match != nil {
+		return normalizeLogLevel(match[1])
+	}
+	return "INFO"
+}
+
+// normalizeLogLevel 统一为 ERROR、WARN、INFO、DEBUG
+// 数字 0-7 按 syslog severity，10-60 按 pino/bunyan 的级别
+func normalizeLogLevel(level string) string {
+	if value, err := strconv.Atoi(level); err == nil {
+		switch {
+		case value <= 7:
+			return syslogSeverityLevel(value)
+		case value >= 50:
+			return "ERROR"
+		case value >= 40:
+			return "WARN"
+		case value >= 30:
+			return "INFO"
+		default:
+			return "DEBUG"
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "fatal", "panic", "critical", "crit", "alert", "emerg", "emergency", "error", "err", "severe":
+		return "ERROR"
+	case "warn", "warning":
+		return "WARN"
+	case "debug", "trace", "verbose":
+		return "DEBUG"
+	default:
+		return "INFO"
+	}
+}
+
+func syslogSeverityLevel(severity int) string {
+	switch {
+	case severity <= 3:
+		return "ERROR"
+	case severity == 4:
+		return "WARN"
+	case severity == 7:
+		return "DEBUG"
+	default:
+		return "INFO"
+	}
+}
+
+func httpStatusLevel(status int) string {
+	switch {
+	case status >= 500:
+		return "ERROR"
+	case status >= 400:
+		return "WARN"
+	default:
+		return "INFO"
+	}
+}
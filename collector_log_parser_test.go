@@ -0,0 +1,244 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogParserFormats(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+
+	tests := []struct {
+		name    string
+		config  LogParserConfig
+		line    string
+		level   string
+		message string
+		time    time.Time
+		fields  map[string]string
+	}{
+		{
+			name:    "json with nested fields",
+			config:  LogParserConfig{Format: "json"},
+			line:    `{"ts":"2024-02-29T10:00:00.5Z","level":"warn","msg":"slow query","db":{"name":"orders"},"duration_ms":1520}`,
+			level:   "WARN",
+			message: "slow query",
+			time:    time.Date(2024, 2, 29, 10, 0, 0, 500000000, time.UTC),
+			fields:  map[string]string{"db.name": "orders", "duration_ms": "1520"},
+		},
+		{
+			name:    "json with field mappings and pino levels",
+			config:  LogParserConfig{Format: "json", MessageField: "event", LevelField: "lvl_num", TimeField: "at", TimeLayout: "unix_ms"},
+			line:    `{"at":1709200800000,"lvl_num":50,"event":"payment failed","level":"ignored"}`,
+			level:   "ERROR",
+			message: "payment failed",
+			time:    time.UnixMilli(1709200800000),
+			fields:  map[string]string{"level": "ignored"},
+		},
+		{
+			name:    "regex with timezone",
+			config:  LogParserConfig{Format: "regex", Pattern: `^(?P<time>\S+ \S+) \[(?P<thread>[^\]]+)\] (?P<level>\w+) (?P<message>.*)$`, Timezone: "Asia/Shanghai"},
+			line:    "2024-02-29 18:00:00,123 [main] ERROR connection refused",
+			level:   "ERROR",
+			message: "connection refused",
+			time:    time.Date(2024, 2, 29, 18, 0, 0, 123000000, shanghai),
+			fields:  map[string]string{"thread": "main"},
+		},
+		{
+			name:    "nginx access",
+			config:  LogParserConfig{Format: "nginx"},
+			line:    `10.0.0.1 - - [29/Feb/2024:10:00:00 +0800] "GET /api/orders?id=1 HTTP/1.1" 502 157 "-" "curl/8.0"`,
+			level:   "ERROR",
+			message: `10.0.0.1 - - [29/Feb/2024:10:00:00 +0800] "GET /api/orders?id=1 HTTP/1.1" 502 157 "-" "curl/8.0"`,
+			time:    time.Date(2024, 2, 29, 2, 0, 0, 0, time.UTC),
+			fields: map[string]string{
+				"remote_addr": "10.0.0.1", "method": "GET", "path": "/api/orders?id=1", "protocol": "HTTP/1.1",
+				"status": "502", "body_bytes_sent": "157", "user_agent": "curl/8.0",
+			},
+		},
+		{
+			name:    "nginx error",
+			config:  LogParserConfig{Format: "nginx", Timezone: "UTC"},
+			line:    "2024/02/29 10:00:00 [crit] 1234#0: *56 connect() failed (111: Connection refused)",
+			level:   "ERROR",
+			message: "connect() failed (111: Connection refused)",
+			time:    time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+			fields:  map[string]string{"pid": "1234", "tid": "0", "connection": "56"},
+		},
+		{
+			name:    "apache error",
+			config:  LogParserConfig{Format: "apache", Timezone: "UTC"},
+			line:    "[Thu Feb 29 10:00:00.123456 2024] [proxy:warn] [pid 99] [client 10.0.0.2:5000] AH01144: No protocol handler",
+			level:   "WARN",
+			message: "AH01144: No protocol handler",
+			time:    time.Date(2024, 2, 29, 10, 0, 0, 123456000, time.UTC),
+			fields:  map[string]string{"module": "proxy", "pid": "99", "client": "10.0.0.2:5000"},
+		},
+		{
+			name:    "syslog rfc3164 without year",
+			config:  LogParserConfig{Format: "syslog", Timezone: "UTC"},
+			line:    "<11>Feb 29 09:59:58 web01 sshd[812]: error: maximum authentication attempts exceeded",
+			level:   "ERROR",
+			message: "error: maximum authentication attempts exceeded",
+			time:    time.Date(2024, 2, 29, 9, 59, 58, 0, time.UTC),
+			fields:  map[string]string{"facility": "1", "host": "web01", "app": "sshd", "pid": "812"},
+		},
+		{
+			name:    "syslog rfc5424",
+			config:  LogParserConfig{Format: "syslog"},
+			line:    "<165>1 2024-02-29T10:00:00Z host01 app 42 ID47 - disk almost full",
+			level:   "INFO",
+			message: "disk almost full",
+			time:    time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+			fields:  map[string]string{"facility": "20", "host": "host01", "app": "app", "pid": "42", "msgid": "ID47"},
+		},
+		{
+			name:    "logfmt",
+			config:  LogParserConfig{Format: "logfmt"},
+			line:    `time=2024-02-29T10:00:00Z level=error msg="upstream timeout" upstream=10.0.0.3:8080 retry`,
+			level:   "ERROR",
+			message: "upstream timeout",
+			time:    time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+			fields:  map[string]string{"upstream": "10.0.0.3:8080", "retry": "true"},
+		},
+		{
+			name:    "json parser falls back to plain",
+			config:  LogParserConfig{Format: "json"},
+			line:    "panic: runtime error: index out of range",
+			level:   "INFO",
+			message: "panic: runtime error: index out of range",
+			time:    now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Paths = []string{"*"}
+			parser, err := newLogParser(tt.config)
+			if err != nil {
+				t.Fatalf("newLogParser: %v", err)
+			}
+			parsed := parser.parse(tt.line, now)
+			if parsed.level != tt.level || parsed.message != tt.message || !parsed.timestamp.Equal(tt.time) {
+				t.Fatalf("got level=%s message=%q time=%s", parsed.level, parsed.message, parsed.timestamp)
+			}
+			if len(parsed.fields) != len(tt.fields) {
+				t.Fatalf("unexpected fields: %v", parsed.fields)
+			}
+			for name, value := range tt.fields {
+				if parsed.fields[name] != value {
+					t.Fatalf("field %s: got %q want %q (all %v)", name, parsed.fields[name], value, parsed.fields)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectLogLevelAvoidsFalsePositives(t *testing.T) {
+	cases := map[string]string{
+		"backup finished with no errors":              "INFO",
+		"2024-02-29 10:00:00 ERROR disk failure":      "ERROR",
+		"[warning] retrying request":                  "WARN",
+		`level=debug msg="cache hit" error_count=0`:   "DEBUG",
+		"handled request, errors: 0, warnings: 0":     "INFO",
+		"FATAL could not bind port":                   "ERROR",
+		"time=2024 severity: Warn something happened": "WARN",
+	}
+	for line, want := range cases {
+		if got := detectLogLevel(line); got != want {
+			t.Errorf("detectLogLevel(%q) = %s, want %s", line, got, want)
+		}
+	}
+}
+
+func TestLogParserRejectsInvalidConfig(t *testing.T) {
+	for _, config := range []LogParserConfig{
+		{Format: "json"},
+		{Paths: []string{"*"}, Format: "xml"},
+		{Paths: []string{"*"}, Format: "regex"},
+		{Paths: []string{"*"}, Format: "regex", Pattern: "("},
+		{Paths: []string{"*"}, Format: "json", Timezone: "Mars/Olympus"},
+	} {
+		if _, err := newLogParser(config); err == nil {
+			t.Errorf("expected error for %+v", config)
+		}
+	}
+}
+
+func TestLogCollectorAppliesParserPerPath(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "api", "app.json")
+	plainPath := filepath.Join(dir, "worker.log")
+	appendLog(t, plainPath)
+	if err := os.MkdirAll(filepath.Dir(jsonPath), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	appendLog(t, jsonPath)
+
+	c := newTestLogCollector([]string{filepath.Join(dir, "**")}, "", LogConfig{
+		Parsers: []LogParserConfig{{Paths: []string{filepath.Join(dir, "api", "*.json")}, Format: "json"}},
+	})
+	c.redactor = NewRedactor(RedactionConfig{Enabled: true, Mask: "***"})
+	collectLogMessages(t, c)
+
+	appendLog(t, jsonPath, `{"time":"2024-02-29T10:00:00Z","level":"error","msg":"login failed","user":"alice","password":"hunter2"}`)
+	appendLog(t, plainPath, "job done, no errors", "ERROR job failed")
+
+	data, err := c.Collect()
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	entries := data.(*LogMetrics).Entries
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %#v", entries)
+	}
+	byMessage := map[string]LogEntry{}
+	for _, entry := range entries {
+		byMessage[entry.Message] = entry
+	}
+	structured, ok := byMessage["login failed"]
+	if !ok || structured.Timestamp != time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC).Unix() {
+		t.Fatalf("unexpected structured entry: %#v", entries)
+	}
+	if structured.Tags["user"] != "alice" || structured.Tags["password"] != "***" || structured.Tags["file"] != jsonPath {
+		t.Fatalf("unexpected tags: %v", structured.Tags)
+	}
+	if _, ok := byMessage["ERROR job failed"]; !ok {
+		t.Fatalf("plain file should keep plain parsing: %#v", entries)
+	}
+}
+
+func TestLogCollectorMatchesRelativeParserPaths(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("logs", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	appendLog(t, filepath.Join("logs", "app.json"))
+	appendLog(t, filepath.Join("logs", "old.json"))
+
+	c := newTestLogCollector([]string{"./logs/**"}, "", LogConfig{
+		Parsers: []LogParserConfig{{Paths: []string{"./logs/*.json"}, Format: "json"}},
+	})
+	collectLogMessages(t, c)
+	appendLog(t, filepath.Join("logs", "app.json"), `{"level":"error","msg":"relative match"}`)
+	appendLog(t, filepath.Join("logs", "old.json"), `{"level":"info","msg":"ignored"}`)
+	if got := collectLogMessages(t, c); len(got) != 1 || got[0] != "relative match" {
+		t.Fatalf("relative parser path should match discovered files, got %v", got)
+	}
+
+	// 不再跟踪的文件同时移除解析器缓存
+	if err := os.Remove(filepath.Join("logs", "old.json")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	c.lastScan = time.Time{}
+	if len(c.fileParsers) != 2 {
+		t.Fatalf("expected both files to have cached parsers, got %v", c.fileParsers)
+	}
+	collectLogMessages(t, c)
+	if _, ok := c.fileParsers[filepath.Join("logs", "old.json")]; ok || len(c.fileParsers) != 1 {
+		t.Fatalf("parser cache should follow tracked files, got %v", c.fileParsers)
+	}
+}
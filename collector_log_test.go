@@ -0,0 +1,328 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func appendLog(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer file.Close()
+	for _, line := range lines {
+		if _, err := file.WriteString(line + "\n"); err != nil {
+			t.Fatalf("write log: %v", err)
+		}
+	}
+}
+
+func collectLogMessages(t *testing.T, c *LogCollector) []string {
+	t.Helper()
+	data, err := c.Collect()
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	var messages []string
+	for _, entry := range data.(*LogMetrics).Entries {
+		messages = append(messages, entry.Message)
+	}
+	c.Commit()
+	return messages
+}
+
+func newTestLogCollector(paths []string, positions string, config LogConfig) *LogCollector {
+	config.PositionsFile = positions
+	return NewLogCollector(paths, config, nil)
+}
+
+func TestLogCollectorTailsNewLinesAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	positions := filepath.Join(dir, "state", "positions.json")
+	appendLog(t, path, "ERROR before agent start")
+
+	c := newTestLogCollector([]string{path}, positions, LogConfig{})
+	if got := collectLogMessages(t, c); len(got) != 0 {
+		t.Fatalf("existing content should be skipped on first run, got %v", got)
+	}
+
+	appendLog(t, path, "ERROR first", "INFO ignored", "WARN second")
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR first|WARN second" {
+		t.Fatalf("unexpected entries: %v", got)
+	}
+	if got := collectLogMessages(t, c); len(got) != 0 {
+		t.Fatalf("lines must not be reported twice, got %v", got)
+	}
+
+	// 重启后从持久化的位置继续
+	appendLog(t, path, "ERROR while restarting")
+	restarted := newTestLogCollector([]string{path}, positions, LogConfig{})
+	if got := collectLogMessages(t, restarted); strings.Join(got, "|") != "ERROR while restarting" {
+		t.Fatalf("unexpected entries after restart: %v", got)
+	}
+}
+
+func TestLogCollectorRereadsWhenNotCommitted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path)
+	c := newTestLogCollector([]string{path}, "", LogConfig{})
+	collectLogMessages(t, c)
+
+	appendLog(t, path, "ERROR report failed")
+	if _, err := c.Collect(); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR report failed" {
+		t.Fatalf("uncommitted lines should be read again, got %v", got)
+	}
+}
+
+func TestLogCollectorHandlesRenameRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path, "ERROR old")
+	c := newTestLogCollector([]string{path}, "", LogConfig{StartAt: "beginning"})
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR old" {
+		t.Fatalf("unexpected entries: %v", got)
+	}
+
+	appendLog(t, path, "ERROR written before rotation")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	appendLog(t, path, "ERROR in new file")
+
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR written before rotation|ERROR in new file" {
+		t.Fatalf("unexpected entries after rotation: %v", got)
+	}
+}
+
+func TestLogCollectorHandlesCopyTruncate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path, "ERROR old")
+	c := newTestLogCollector([]string{path}, "", LogConfig{StartAt: "beginning"})
+	collectLogMessages(t, c)
+
+	appendLog(t, path, "ERROR written before truncate")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if err := os.WriteFile(path+"-20240101", data, 0644); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	appendLog(t, path, "WARN after truncate")
+
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR written before truncate|WARN after truncate" {
+		t.Fatalf("unexpected entries after copytruncate: %v", got)
+	}
+}
+
+func TestLogCollectorFinishesCopyTruncateAcrossCycles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path, "ERROR old")
+	c := newTestLogCollector([]string{path}, "", LogConfig{StartAt: "beginning", MaxLinesPerCycle: 1})
+	collectLogMessages(t, c)
+
+	appendLog(t, path, "ERROR copied 1", "ERROR copied 2")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if err := os.WriteFile(path+".1", data, 0644); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	appendLog(t, path, "WARN after truncate")
+
+	// 复制出的文件超出额度的部分在后续周期继续读取，不会丢失
+	var got []string
+	for i := 0; i < 3; i++ {
+		got = append(got, collectLogMessages(t, c)...)
+	}
+	if strings.Join(got, "|") != "ERROR copied 1|ERROR copied 2|WARN after truncate" {
+		t.Fatalf("unexpected entries after copytruncate: %v", got)
+	}
+}
+
+func TestLogCollectorRespectsBudgets(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path, "ERROR 1", "ERROR 2", "ERROR 3", "ERROR 4", "ERROR 5")
+	c := newTestLogCollector([]string{path}, "", LogConfig{StartAt: "beginning", MaxLinesPerCycle: 2})
+
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR 1|ERROR 2" {
+		t.Fatalf("unexpected first batch: %v", got)
+	}
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR 3|ERROR 4" {
+		t.Fatalf("remaining lines should be read next cycle: %v", got)
+	}
+
+	// 未写完的半行留到下个周期
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString("ERROR partial")
+	file.Close()
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR 5" {
+		t.Fatalf("partial line should wait: %v", got)
+	}
+	appendLog(t, path, " line")
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR partial line" {
+		t.Fatalf("completed line should be read: %v", got)
+	}
+
+	// 超过字节额度的长行截断读取，不会卡住后续内容
+	long := newTestLogCollector([]string{path}, "", LogConfig{MaxBytesPerCycle: 16})
+	collectLogMessages(t, long)
+	appendLog(t, path, "ERROR "+strings.Repeat("x", 40), "ERROR next")
+	for i := 0; i < 10; i++ {
+		if got := collectLogMessages(t, long); strings.Join(got, "|") == "ERROR next" {
+			return
+		}
+	}
+	t.Fatal("long line blocked the following lines")
+}
+
+func TestLogCollectorKeepsLineWhenBudgetPartlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path, "ERROR old")
+	c := newTestLogCollector([]string{path}, "", LogConfig{StartAt: "beginning", MaxBytesPerCycle: 24})
+	collectLogMessages(t, c)
+
+	// 轮转前的文件用掉一部分额度，新文件中放得下整个周期额度的行不能被截断
+	appendLog(t, path, "ERROR rotated")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	appendLog(t, path, "ERROR 0123456789")
+
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR rotated" {
+		t.Fatalf("unexpected first cycle: %v", got)
+	}
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR 0123456789" {
+		t.Fatalf("line should be read whole in the next cycle: %v", got)
+	}
+}
+
+func TestLogFileFinderExpandsPatterns(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"app/app.log", "app/app.log.1", "app/app.log.2.gz", "app/notes.txt",
+		"nested/a/one.log", "nested/b/c/two.log", "nested/three.log",
+		"plain/x.out", "plain/sub/y.out",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		appendLog(t, path, "line")
+	}
+	missing := filepath.Join(dir, "later.log")
+
+	finder := &logFileFinder{
+		patterns: []string{
+			filepath.Join(dir, "app", "*"),
+			filepath.Join(dir, "nested", "**", "*.log"),
+			filepath.Join(dir, "plain"),
+			missing,
+		},
+		exclude:  []string{"**/*.gz", "**/*.[0-9]"},
+		maxFiles: 100,
+	}
+	var got []string
+	for _, path := range finder.discover() {
+		rel, _ := filepath.Rel(dir, path)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	want := "app/app.log|app/notes.txt|later.log|nested/a/one.log|nested/b/c/two.log|nested/three.log|plain/x.out"
+	if strings.Join(got, "|") != want {
+		t.Fatalf("unexpected files:\n got %v\nwant %s", got, want)
+	}
+
+	finder.maxFiles = 1
+	old := time.Now().Add(-time.Hour)
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			os.Chtimes(path, old, old)
+		}
+		return nil
+	})
+	os.Chtimes(filepath.Join(dir, "nested", "b", "c", "two.log"), time.Now(), time.Now())
+	if got := finder.discover(); len(got) != 1 || got[0] != filepath.Join(dir, "nested", "b", "c", "two.log") {
+		t.Fatalf("expected only the newest file, got %v", got)
+	}
+}
+
+func TestLogFileFinderExpandsRelativePatterns(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"logs/app.log", "logs/api/access.log"} {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		appendLog(t, name, "line")
+	}
+
+	finder := &logFileFinder{patterns: []string{"./logs/**/*.log"}, maxFiles: 100}
+	got := finder.discover()
+	want := []string{filepath.Join("logs", "api", "access.log"), filepath.Join("logs", "app.log")}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected files: got %v want %v", got, want)
+	}
+}
+
+func TestLogCollectorDiscoversNewFilesOnRescan(t *testing.T) {
+	dir := t.TempDir()
+	appendLog(t, filepath.Join(dir, "app-2024-01-01.log"), "ERROR old day")
+	c := newTestLogCollector([]string{filepath.Join(dir, "*.log")}, "", LogConfig{RescanInterval: 60})
+	start := time.Unix(1700000000, 0)
+	if metrics, _ := c.collectAt(start); len(metrics.Entries) != 0 {
+		t.Fatalf("existing files should start at end: %#v", metrics.Entries)
+	}
+	c.Commit()
+
+	appendLog(t, filepath.Join(dir, "app-2024-01-02.log"), "ERROR new day")
+	if metrics, _ := c.collectAt(start.Add(30 * time.Second)); len(metrics.Entries) != 0 {
+		t.Fatalf("new file should wait for rescan: %#v", metrics.Entries)
+	}
+	c.Commit()
+	metrics, _ := c.collectAt(start.Add(60 * time.Second))
+	if len(metrics.Entries) != 1 || metrics.Entries[0].Message != "ERROR new day" {
+		t.Fatalf("new file should be read from the beginning: %#v", metrics.Entries)
+	}
+}
+
+func TestLogCollectorFollowsRotationWithinGlob(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path, "ERROR old")
+	c := newTestLogCollector([]string{filepath.Join(dir, "*.log")}, "", LogConfig{RescanInterval: 3600})
+	collectLogMessages(t, c)
+
+	appendLog(t, path, "ERROR before rotation")
+	if err := os.Rename(path, filepath.Join(dir, "app-20240101.log")); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	appendLog(t, path, "ERROR after rotation")
+
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR before rotation|ERROR after rotation" {
+		t.Fatalf("unexpected entries: %v", got)
+	}
+	appendLog(t, filepath.Join(dir, "app-20240101.log"), "ERROR late write")
+	if got := collectLogMessages(t, c); strings.Join(got, "|") != "ERROR late write" {
+		t.Fatalf("rotated file should keep its position: %v", got)
+	}
+}
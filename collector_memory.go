@@ -22,13 +22,57 @@ func (c *MemoryCollector) Collect() (interface{}, error) {
 		return nil, err
 	}
 
+	// 交换分区失败时退回 VirtualMemory 中的 SwapTotal/SwapFree
+	swapStat, err := mem.SwapMemory()
+	if err != nil {
+		swapStat = nil
+	}
+
+	return memoryMetricsFromStats(vmStat, swapStat), nil
+}
+
+func memoryMetricsFromStats(vmStat *mem.VirtualMemoryStat, swapStat *mem.SwapMemoryStat) *MemoryMetrics {
 	metrics := &MemoryMetrics{
-		Total:       vmStat.Total,
-		Used:        vmStat.Used,
-		Free:        vmStat.Free,
-		UsedPercent: vmStat.UsedPercent,
-		Available:   vmStat.Available,
+		Total:             vmStat.Total,
+		Used:              vmStat.Used,
+		Free:              vmStat.Free,
+		UsedPercent:       vmStat.UsedPercent,
+		Available:         vmStat.Available,
+		Buffers:           vmStat.Buffers,
+		Cached:            vmStat.Cached,
+		Shared:            vmStat.Shared,
+		SlabReclaimable:   vmStat.Sreclaimable,
+		SlabUnreclaimable: vmStat.Sunreclaim,
+		Dirty:             vmStat.Dirty,
+		Writeback:         vmStat.WriteBack,
+		CommittedAS:       vmStat.CommittedAS,
+		CommitLimit:       vmStat.CommitLimit,
+		HugePagesTotal:    vmStat.HugePagesTotal,
+		HugePagesFree:     vmStat.HugePagesFree,
+		HugePagesReserved: vmStat.HugePagesRsvd,
+		HugePageSize:      vmStat.HugePageSize,
+		AnonHugePages:     vmStat.AnonHugePages,
+	}
+
+	if swapStat != nil {
+		metrics.SwapTotal = swapStat.Total
+		metrics.SwapUsed = swapStat.Used
+		metrics.SwapFree = swapStat.Free
+		metrics.SwapUsedPercent = swapStat.UsedPercent
+	} else if vmStat.SwapTotal > 0 {
+		metrics.SwapTotal = vmStat.SwapTotal
+		metrics.SwapFree = vmStat.SwapFree
+		metrics.SwapUsed = vmStat.SwapTotal - vmStat.SwapFree
+		metrics.SwapUsedPercent = float64(metrics.SwapUsed) / float64(metrics.SwapTotal) * 100
+	}
+
+	if metrics.CommitLimit > 0 {
+		metrics.CommitPercent = float64(metrics.CommittedAS) / float64(metrics.CommitLimit) * 100
+	}
+	if metrics.HugePagesTotal > 0 {
+		used := metrics.HugePagesTotal - metrics.HugePagesFree
+		metrics.HugePagesUsedPercent = float64(used) / float64(metrics.HugePagesTotal) * 100
 	}
 
-	return metrics, nil
+	return metrics
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+func TestMemoryMetricsFromStats(t *testing.T) {
+	vmStat := &mem.VirtualMemoryStat{
+		Total:          1000,
+		Used:           600,
+		Buffers:        10,
+		Cached:         200,
+		Sreclaimable:   30,
+		Sunreclaim:     20,
+		Dirty:          5,
+		WriteBack:      1,
+		CommittedAS:    1500,
+		CommitLimit:    2000,
+		HugePagesTotal: 8,
+		HugePagesFree:  2,
+		HugePageSize:   2 * 1024 * 1024,
+	}
+	swapStat := &mem.SwapMemoryStat{Total: 400, Used: 100, Free: 300, UsedPercent: 25}
+
+	metrics := memoryMetricsFromStats(vmStat, swapStat)
+
+	if metrics.SwapTotal != 400 || metrics.SwapUsed != 100 || metrics.SwapUsedPercent != 25 {
+		t.Fatalf("unexpected swap: %#v", metrics)
+	}
+	if metrics.SlabReclaimable != 30 || metrics.SlabUnreclaimable != 20 || metrics.Cached != 200 {
+		t.Fatalf("unexpected cache/slab: %#v", metrics)
+	}
+	if metrics.CommitPercent != 75 {
+		t.Fatalf("expected commit percent 75, got %.2f", metrics.CommitPercent)
+	}
+	if metrics.HugePagesUsedPercent != 75 {
+		t.Fatalf("expected hugepages used percent 75, got %.2f", metrics.HugePagesUsedPercent)
+	}
+}
+
+func TestMemoryMetricsFallsBackToVirtualMemorySwap(t *testing.T) {
+	metrics := memoryMetricsFromStats(&mem.VirtualMemoryStat{SwapTotal: 200, SwapFree: 150}, nil)
+
+	if metrics.SwapUsed != 50 || metrics.SwapUsedPercent != 25 {
+		t.Fatalf("unexpected swap fallback: used=%d percent=%.2f", metrics.SwapUsed, metrics.SwapUsedPercent)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NetstackCollector 协议栈采集器：按 TCP 状态统计连接数，并读取 /proc/net/snmp、netstat、snmp6 中的重传、
+// 全连接队列溢出、SYN cookie、UDP 缓冲区错误等计数器，按采集间隔计算速率
+type NetstackCollector struct {
+	procRoot string
+	prev     map[string]uint64
+	prevTime time.Time
+}
+
+// /proc/net/tcp 中 st 字段的十六进制状态码
+var tcpStateNames = map[uint64]string{
+	0x01: "ESTABLISHED",
+	0x02: "SYN_SENT",
+	0x03: "SYN_RECV",
+	0x04: "FIN_WAIT1",
+	0x05: "FIN_WAIT2",
+	0x06: "TIME_WAIT",
+	0x07: "CLOSE",
+	0x08: "CLOSE_WAIT",
+	0x09: "LAST_ACK",
+	0x0A: "LISTEN",
+	0x0B: "CLOSING",
+	0x0C: "NEW_SYN_RECV",
+}
+
+// procNetSocket /proc/net/{tcp,tcp6,udp,udp6} 中的一行
+type procNetSocket struct {
+	LocalAddr  string
+	LocalPort  int
+	RemoteAddr string
+	RemotePort int
+	State      uint64
+	UID        int
+	Inode      uint64
+}
+
+func NewNetstackCollector(procRoot string) *NetstackCollector {
+	if procRoot == "" {
+		procRoot = "/proc"
+	}
+	return &NetstackCollector{procRoot: procRoot}
+}
+
+func (c *NetstackCollector) Name() string {
+	return "netstack"
+}
+
+func (c *NetstackCollector) Collect() (interface{}, error) {
+	return c.collectAt(time.Now())
+}
+
+func (c *NetstackCollector) collectAt(now time.Time) (*NetstackMetrics, error) {
+	metrics := &NetstackMetrics{TCPStates: make(map[string]uint64)}
+
+	// tcp6 在关闭 IPv6 的主机上不存在，只要有一个能读就继续
+	readable := false
+	for _, name := range []string{"tcp", "tcp6"} {
+		sockets, err := parseProcNetSockets(filepath.Join(c.procRoot, "net", name))
+		if err != nil {
+			continue
+		}
+		readable = true
+		for _, socket := range sockets {
+			state, ok := tcpStateNames[socket.State]
+			if !ok {
+				state = "UNKNOWN"
+			}
+			metrics.TCPStates[state]++
+			metrics.TCPSockets++
+		}
+	}
+	if !readable {
+		return nil, fmt.Errorf("no readable tcp socket table under %s", filepath.Join(c.procRoot, "net"))
+	}
+	for _, name := range []string{"udp", "udp6"} {
+		if sockets, err := parseProcNetSockets(filepath.Join(c.procRoot, "net", name)); err == nil {
+			metrics.UDPSockets += uint64(len(sockets))
+		}
+	}
+
+	counters := readNetstackCounters(c.procRoot)
+	metrics.TCPCurrEstab = counters["Tcp.CurrEstab"]
+	if !c.prevTime.IsZero() {
+		elapsed := now.Sub(c.prevTime).Seconds()
+		rate := func(key string) float64 {
+			return counterRate(c.prev[key], counters[key], elapsed)
+		}
+		metrics.TCPActiveOpensPerSec = rate("Tcp.ActiveOpens")
+		metrics.TCPPassiveOpensPerSec = rate("Tcp.PassiveOpens")
+		metrics.TCPAttemptFailsPerSec = rate("Tcp.AttemptFails")
+		metrics.TCPEstabResetsPerSec = rate("Tcp.EstabResets")
+		metrics.TCPInErrsPerSec = rate("Tcp.InErrs")
+		metrics.TCPOutRstsPerSec = rate("Tcp.OutRsts")
+		metrics.TCPOutSegsPerSec = rate("Tcp.OutSegs")
+		metrics.TCPRetransSegsPerSec = rate("Tcp.RetransSegs")
+		if metrics.TCPOutSegsPerSec > 0 {
+			metrics.TCPRetransPercent = clampPercent(metrics.TCPRetransSegsPerSec / metrics.TCPOutSegsPerSec * 100)
+		}
+		metrics.ListenOverflowsPerSec = rate("TcpExt.ListenOverflows")
+		metrics.ListenDropsPerSec = rate("TcpExt.ListenDrops")
+		metrics.SyncookiesSentPerSec = rate("TcpExt.SyncookiesSent")
+		metrics.SyncookiesRecvPerSec = rate("TcpExt.SyncookiesRecv")
+		metrics.SyncookiesFailedPerSec = rate("TcpExt.SyncookiesFailed")
+		metrics.UDPInErrorsPerSec = rate("Udp.InErrors")
+		metrics.UDPNoPortsPerSec = rate("Udp.NoPorts")
+		metrics.UDPRcvbufErrorsPerSec = rate("Udp.RcvbufErrors")
+		metrics.UDPSndbufErrorsPerSec = rate("Udp.SndbufErrors")
+	}
+
+	c.prev = counters
+	c.prevTime = now
+	return metrics, nil
+}
+
+// readNetstackCounters 合并 snmp、netstat 和 snmp6 计数器，键为 "<协议>.<字段>"
+// Udp6 计数器累加到 Udp 上，使 UDP 错误同时覆盖 IPv4 和 IPv6
+func readNetstackCounters(procRoot string) map[string]uint64 {
+	counters := make(map[string]uint64)
+	for _, name := range []string{"snmp", "netstat"} {
+		values, err := parseProcNetSNMP(filepath.Join(procRoot, "net", name))
+		if err != nil {
+			continue
+		}
+		for key, value := range values {
+			counters[key] = value
+		}
+	}
+	if snmp6, err := readProcKeyValues(filepath.Join(procRoot, "net", "snmp6")); err == nil {
+		for key, value := range snmp6 {
+			if field, ok := strings.CutPrefix(key, "Udp6"); ok {
+				counters["Udp."+field] += value
+			}
+		}
+	}
+	return counters
+}
+
+// parseProcNetSNMP 解析表头行与数值行成对出现的格式：
+//
+//	Tcp: RtoAlgorithm RtoMin ...
+//	Tcp: 1 200 ...
+//
+// 负值（如 Tcp.MaxConn 的 -1）忽略
+func parseProcNetSNMP(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	var header []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		if header == nil || header[0] != fields[0] {
+			header = fields
+			continue
+		}
+		prefix := strings.TrimSuffix(fields[0], ":")
+		for i := 1; i < len(fields) && i < len(header); i++ {
+			if value, err := strconv.ParseUint(fields[i], 10, 64); err == nil {
+				values[prefix+"."+header[i]] = value
+			}
+		}
+		header = nil
+	}
+	return values, scanner.Err()
+}
+
+// parseProcNetSockets 解析 /proc/net/{tcp,tcp6,udp,udp6}
+func parseProcNetSockets(path string) ([]procNetSocket, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sockets []procNetSocket
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // 跳过表头
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localAddr, localPort, err := parseProcNetAddr(fields[1])
+		if err != nil {
+			continue
+		}
+		remoteAddr, remotePort, err := parseProcNetAddr(fields[2])
+		if err != nil {
+			continue
+		}
+		state, _ := strconv.ParseUint(fields[3], 16, 8)
+		uid, _ := strconv.Atoi(fields[7])
+		inode, _ := strconv.ParseUint(fields[9], 10, 64)
+		sockets = append(sockets, procNetSocket{
+			LocalAddr:  localAddr,
+			LocalPort:  localPort,
+			RemoteAddr: remoteAddr,
+			RemotePort: remotePort,
+			State:      state,
+			UID:        uid,
+			Inode:      inode,
+		})
+	}
+	return sockets, scanner.Err()
+}
+
+// parseProcNetAddr 解析 "0100007F:1F90" 形式的地址，地址按32位字以主机字节序（小端）存储
+func parseProcNetAddr(value string) (string, int, error) {
+	addrHex, portHex, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid address %q", value)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", 0, err
+	}
+	raw, err := hex.DecodeString(addrHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, fmt.Errorf("invalid address %q", value)
+	}
+	ip := make(net.IP, len(raw))
+	for word := 0; word < len(raw); word += 4 {
+		for i := 0; i < 4; i++ {
+			ip[word+i] = raw[word+3-i]
+		}
+	}
+	return ip.String(), int(port), nil
+}
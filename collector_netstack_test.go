@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testProcNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1001 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000  1000        0 1002 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:1F90 0100007F:C351 06 00000000:00000000 03:00000000 00000000     0        0 0 3 0000000000000000
+   3: 0100007F:1F90 0100007F:C352 08 00000000:00000000 00:00000000 00000000  1000        0 1004 1 0000000000000000 20 4 30 10 -1
+`
+
+const testProcNetTCP6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000001000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 2001 1 0000000000000000 100 0 0 10 0
+`
+
+func writeNetstackProcFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, "net")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func netstackSNMP(outSegs, retrans, rcvbufErrors int) string {
+	return "Tcp: RtoAlgorithm RtoMin RtoMax MaxConn ActiveOpens PassiveOpens AttemptFails EstabResets CurrEstab InSegs OutSegs RetransSegs InErrs OutRsts InCsumErrors\n" +
+		fmt.Sprintf("Tcp: 1 200 120000 -1 10 20 0 0 1 100 %d %d 0 0 0\n", outSegs, retrans) +
+		"Udp: InDatagrams NoPorts InErrors OutDatagrams RcvbufErrors SndbufErrors InCsumErrors IgnoredMulti MemErrors\n" +
+		fmt.Sprintf("Udp: 40 0 0 40 %d 0 0 0 0\n", rcvbufErrors)
+}
+
+func netstackNetstat(listenOverflows, syncookies int) string {
+	return "TcpExt: SyncookiesSent SyncookiesRecv SyncookiesFailed ListenOverflows ListenDrops\n" +
+		fmt.Sprintf("TcpExt: %d 0 0 %d %d\n", syncookies, listenOverflows, listenOverflows) +
+		"IpExt: InNoRoutes InTruncatedPkts\nIpExt: 0 0\n"
+}
+
+func TestNetstackCollectorCountsStatesAndRates(t *testing.T) {
+	root := t.TempDir()
+	writeNetstackProcFiles(t, root, map[string]string{
+		"tcp":     testProcNetTCP,
+		"tcp6":    testProcNetTCP6,
+		"udp":     "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops\n  10: 00000000:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 3001 2 0000000000000000 0\n",
+		"snmp":    netstackSNMP(1000, 0, 0),
+		"netstat": netstackNetstat(0, 0),
+		"snmp6":   "Udp6InDatagrams 10\nUdp6RcvbufErrors 5\n",
+	})
+
+	collector := NewNetstackCollector(root)
+	start := time.Unix(1000, 0)
+	first, err := collector.collectAt(start)
+	if err != nil {
+		t.Fatalf("first collect: %v", err)
+	}
+	if first.TCPSockets != 5 || first.UDPSockets != 1 {
+		t.Fatalf("unexpected socket counts: tcp=%d udp=%d", first.TCPSockets, first.UDPSockets)
+	}
+	want := map[string]uint64{"LISTEN": 2, "ESTABLISHED": 1, "TIME_WAIT": 1, "CLOSE_WAIT": 1}
+	for state, count := range want {
+		if first.TCPStates[state] != count {
+			t.Fatalf("expected %d %s sockets, got %v", count, state, first.TCPStates)
+		}
+	}
+	if first.TCPRetransSegsPerSec != 0 {
+		t.Fatalf("expected no rates on first tick, got %#v", first)
+	}
+
+	writeNetstackProcFiles(t, root, map[string]string{
+		"snmp":    netstackSNMP(3000, 100, 20),
+		"netstat": netstackNetstat(50, 10),
+		"snmp6":   "Udp6InDatagrams 10\nUdp6RcvbufErrors 25\n",
+	})
+	metrics, err := collector.collectAt(start.Add(10 * time.Second))
+	if err != nil {
+		t.Fatalf("second collect: %v", err)
+	}
+	if metrics.TCPRetransSegsPerSec != 10 || metrics.TCPRetransPercent != 5 {
+		t.Fatalf("unexpected retransmits: %.2f/s %.2f%%", metrics.TCPRetransSegsPerSec, metrics.TCPRetransPercent)
+	}
+	if metrics.ListenOverflowsPerSec != 5 || metrics.SyncookiesSentPerSec != 1 {
+		t.Fatalf("unexpected netstat rates: overflows=%.2f syncookies=%.2f", metrics.ListenOverflowsPerSec, metrics.SyncookiesSentPerSec)
+	}
+	// IPv4 增加 20，IPv6 增加 20
+	if metrics.UDPRcvbufErrorsPerSec != 4 {
+		t.Fatalf("expected udp rcvbuf errors from both families, got %.2f", metrics.UDPRcvbufErrorsPerSec)
+	}
+}
+
+func TestParseProcNetAddr(t *testing.T) {
+	tests := []struct {
+		value string
+		addr  string
+		port  int
+	}{
+		{"0100007F:1F90", "127.0.0.1", 8080},
+		{"00000000:0016", "0.0.0.0", 22},
+		{"00000000000000000000000001000000:0050", "::1", 80},
+		{"0000000000000000FFFF00000100007F:01BB", "127.0.0.1", 443}, // IPv4 映射地址按 IPv4 显示
+	}
+	for _, tt := range tests {
+		addr, port, err := parseProcNetAddr(tt.value)
+		if err != nil || addr != tt.addr || port != tt.port {
+			t.Fatalf("parseProcNetAddr(%q) = (%q, %d, %v), want (%q, %d)", tt.value, addr, port, err, tt.addr, tt.port)
+		}
+	}
+}
+
+func TestNetstackCollectorWithoutProcNet(t *testing.T) {
+	if _, err := NewNetstackCollector(t.TempDir()).collectAt(time.Now()); err == nil {
+		t.Fatal("expected error when no tcp table is readable")
+	}
+}
@@ -4,11 +4,34 @@
 package main
 
 import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/shirou/gopsutil/v3/net"
 )
 
 // NetworkCollector 网络采集器
-type NetworkCollector struct{}
+// 保存上一次的累计计数，按采集间隔计算每秒速率
+type NetworkCollector struct {
+	config   NetworkConfig
+	prev     map[string]net.IOCountersStat
+	prevTime time.Time
+}
+
+// NewNetworkCollector 创建网络采集器
+func NewNetworkCollector(config NetworkConfig) *NetworkCollector {
+	if config.SysfsRoot == "" {
+		config.SysfsRoot = "/sys/class/net"
+	}
+	return &NetworkCollector{
+		config: config,
+		prev:   make(map[string]net.IOCountersStat),
+	}
+}
 
 // Name 返回采集器名称
 func (c *NetworkCollector) Name() string {
@@ -21,16 +44,30 @@ func (c *NetworkCollector) Collect() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	// 地址获取失败不影响流量数据
+	addresses := make(map[string][]string)
+	if interfaces, err := net.Interfaces(); err == nil {
+		for _, iface := range interfaces {
+			for _, addr := range iface.Addrs {
+				addresses[iface.Name] = append(addresses[iface.Name], addr.Addr)
+			}
+		}
+	}
+	return c.collectAt(ioCounters, addresses, time.Now()), nil
+}
 
+func (c *NetworkCollector) collectAt(ioCounters []net.IOCountersStat, addresses map[string][]string, now time.Time) *NetworkMetrics {
 	metrics := &NetworkMetrics{
 		Interfaces: make([]InterfaceMetrics, 0),
 	}
 
+	elapsed := now.Sub(c.prevTime).Seconds()
+	current := make(map[string]net.IOCountersStat, len(ioCounters))
 	for _, io := range ioCounters {
-		// 过滤lo接口
-		if io.Name == "lo" {
+		if !matchIncludeExclude(io.Name, c.config.IncludeInterfaces, c.config.ExcludeInterfaces) {
 			continue
 		}
+		current[io.Name] = io
 
 		im := InterfaceMetrics{
 			Name:        io.Name,
@@ -40,9 +77,79 @@ func (c *NetworkCollector) Collect() (interface{}, error) {
 			PacketsRecv: io.PacketsRecv,
 			Errin:       io.Errin,
 			Errout:      io.Errout,
+			Dropin:      io.Dropin,
+			Dropout:     io.Dropout,
+			Addresses:   addresses[io.Name],
+		}
+		readInterfaceSysfs(filepath.Join(c.config.SysfsRoot, io.Name), &im)
+		// 首次采集或新出现的网卡没有上一次计数，速率保持为0
+		if prev, ok := c.prev[io.Name]; ok && !c.prevTime.IsZero() && elapsed > 0 {
+			im.BytesSentPerSec = networkCounterRate(prev.BytesSent, io.BytesSent, elapsed)
+			im.BytesRecvPerSec = networkCounterRate(prev.BytesRecv, io.BytesRecv, elapsed)
+			im.PacketsSentPerSec = networkCounterRate(prev.PacketsSent, io.PacketsSent, elapsed)
+			im.PacketsRecvPerSec = networkCounterRate(prev.PacketsRecv, io.PacketsRecv, elapsed)
+			im.ErrinPerSec = networkCounterRate(prev.Errin, io.Errin, elapsed)
+			im.ErroutPerSec = networkCounterRate(prev.Errout, io.Errout, elapsed)
+			im.DropinPerSec = networkCounterRate(prev.Dropin, io.Dropin, elapsed)
+			im.DropoutPerSec = networkCounterRate(prev.Dropout, io.Dropout, elapsed)
+			im.UtilizationPercent = interfaceUtilization(im)
 		}
 		metrics.Interfaces = append(metrics.Interfaces, im)
 	}
 
-	return metrics, nil
+	c.prev = current
+	c.prevTime = now
+	return metrics
+}
+
+// networkCounterRate 计算网卡计数器每秒增量
+// 部分驱动只提供32位计数器：上一次值位于32位上半区且当前值变小时按回绕处理，
+// 其余回退视为计数器重置（网卡重建、驱动重载），本周期速率记为0
+func networkCounterRate(prev, current uint64, seconds float64) float64 {
+	if seconds <= 0 {
+		return 0
+	}
+	if current >= prev {
+		return float64(current-prev) / seconds
+	}
+	if prev <= math.MaxUint32 && prev >= 1<<31 && current <= math.MaxUint32 {
+		return float64(math.MaxUint32-prev+current+1) / seconds
+	}
+	return 0
+}
+
+// readInterfaceSysfs 读取 /sys/class/net/<iface> 下的链路状态、速率、双工、MTU 和 MAC
+// 虚拟网卡读取 speed 会返回错误或 -1，此时速率记为0
+func readInterfaceSysfs(dir string, im *InterfaceMetrics) {
+	im.OperState = readSysfsString(filepath.Join(dir, "operstate"))
+	im.Duplex = readSysfsString(filepath.Join(dir, "duplex"))
+	im.HardwareAddr = readSysfsString(filepath.Join(dir, "address"))
+	if speed, err := strconv.ParseInt(readSysfsString(filepath.Join(dir, "speed")), 10, 64); err == nil && speed > 0 {
+		im.SpeedMbps = speed
+	}
+	if mtu, err := strconv.Atoi(readSysfsString(filepath.Join(dir, "mtu"))); err == nil {
+		im.MTU = mtu
+	}
+}
+
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// interfaceUtilization 按链路速率计算带宽利用率
+// 全双工取收发中较大的一侧，半双工收发共享带宽
+func interfaceUtilization(im InterfaceMetrics) float64 {
+	if im.SpeedMbps <= 0 {
+		return 0
+	}
+	capacity := float64(im.SpeedMbps) * 1e6 / 8
+	used := math.Max(im.BytesSentPerSec, im.BytesRecvPerSec)
+	if im.Duplex == "half" {
+		used = im.BytesSentPerSec + im.BytesRecvPerSec
+	}
+	return clampPercent(used / capacity * 100)
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+func TestNetworkCollectorComputesRates(t *testing.T) {
+	collector := NewNetworkCollector(NetworkConfig{ExcludeInterfaces: []string{"lo"}, SysfsRoot: t.TempDir()})
+	start := time.Unix(1000, 0)
+
+	first := collector.collectAt([]net.IOCountersStat{
+		{Name: "eth0", BytesSent: 1000, BytesRecv: 2000, PacketsSent: 10, PacketsRecv: 20, Dropin: 1},
+		{Name: "lo", BytesSent: 5000},
+	}, nil, start)
+	if len(first.Interfaces) != 1 || first.Interfaces[0].BytesSentPerSec != 0 {
+		t.Fatalf("expected lo filtered and zero rates on first sample, got %#v", first.Interfaces)
+	}
+
+	second := collector.collectAt([]net.IOCountersStat{
+		{Name: "eth0", BytesSent: 11000, BytesRecv: 42000, PacketsSent: 110, PacketsRecv: 220, Errin: 10, Dropin: 21, Dropout: 5},
+	}, nil, start.Add(10*time.Second))
+	iface := second.Interfaces[0]
+	if iface.BytesSentPerSec != 1000 || iface.BytesRecvPerSec != 4000 {
+		t.Fatalf("unexpected byte rates: sent=%.0f recv=%.0f", iface.BytesSentPerSec, iface.BytesRecvPerSec)
+	}
+	if iface.PacketsSentPerSec != 10 || iface.PacketsRecvPerSec != 20 {
+		t.Fatalf("unexpected packet rates: sent=%.0f recv=%.0f", iface.PacketsSentPerSec, iface.PacketsRecvPerSec)
+	}
+	if iface.ErrinPerSec != 1 || iface.DropinPerSec != 2 || iface.DropoutPerSec != 0.5 {
+		t.Fatalf("unexpected error/drop rates: %#v", iface)
+	}
+	if iface.Dropin != 21 || iface.Dropout != 5 {
+		t.Fatalf("expected raw drop counters to be reported, got in=%d out=%d", iface.Dropin, iface.Dropout)
+	}
+}
+
+func TestNetworkCounterRateHandlesWrapAndReset(t *testing.T) {
+	if rate := networkCounterRate(math.MaxUint32-99, 100, 10); rate != 20 {
+		t.Fatalf("expected 32-bit wrap to yield 20/s, got %.2f", rate)
+	}
+	if rate := networkCounterRate(1<<40, 100, 10); rate != 0 {
+		t.Fatalf("expected 64-bit counter reset to yield 0, got %.2f", rate)
+	}
+	if rate := networkCounterRate(5000, 100, 10); rate != 0 {
+		t.Fatalf("expected small counter reset to yield 0, got %.2f", rate)
+	}
+}
+
+func writeInterfaceAttrs(t *testing.T, root, name string, attrs map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for attr, value := range attrs {
+		if err := os.WriteFile(filepath.Join(dir, attr), []byte(value), 0644); err != nil {
+			t.Fatalf("write %s: %v", attr, err)
+		}
+	}
+}
+
+func TestNetworkCollectorReadsSysfsAndFiltersInterfaces(t *testing.T) {
+	root := t.TempDir()
+	writeInterfaceAttrs(t, root, "eth0", map[string]string{
+		"operstate": "up\n",
+		"speed":     "1000\n",
+		"duplex":    "full\n",
+		"mtu":       "9000\n",
+		"address":   "52:54:00:12:34:56\n",
+	})
+	writeInterfaceAttrs(t, root, "veth1a2b", map[string]string{"speed": "-1\n"})
+
+	config := LoadAgentConfigFromPath(filepath.Join(t.TempDir(), "missing.yaml")).Network
+	config.SysfsRoot = root
+	collector := NewNetworkCollector(config)
+	addresses := map[string][]string{"eth0": {"10.0.0.5/24", "fe80::1/64"}}
+	start := time.Unix(1000, 0)
+
+	counters := []net.IOCountersStat{{Name: "eth0"}, {Name: "veth1a2b"}, {Name: "docker0"}, {Name: "lo"}}
+	collector.collectAt(counters, addresses, start)
+	metrics := collector.collectAt([]net.IOCountersStat{
+		{Name: "eth0", BytesSent: 250_000_000, BytesRecv: 50_000_000},
+	}, addresses, start.Add(10*time.Second))
+
+	if len(metrics.Interfaces) != 1 {
+		t.Fatalf("expected only eth0 after default excludes, got %#v", metrics.Interfaces)
+	}
+	iface := metrics.Interfaces[0]
+	if iface.OperState != "up" || iface.SpeedMbps != 1000 || iface.Duplex != "full" || iface.MTU != 9000 || iface.HardwareAddr != "52:54:00:12:34:56" {
+		t.Fatalf("unexpected sysfs metadata: %#v", iface)
+	}
+	if len(iface.Addresses) != 2 {
+		t.Fatalf("expected addresses to be attached, got %v", iface.Addresses)
+	}
+	// 25MB/s 发送 = 200Mbps，占 1000Mbps 的 20%
+	if iface.UtilizationPercent != 20 {
+		t.Fatalf("expected 20%% utilization, got %.2f", iface.UtilizationPercent)
+	}
+}
+
+func TestInterfaceUtilizationUnknownSpeed(t *testing.T) {
+	if util := interfaceUtilization(InterfaceMetrics{BytesSentPerSec: 1e6}); util != 0 {
+		t.Fatalf("expected 0 utilization without link speed, got %.2f", util)
+	}
+	half := InterfaceMetrics{SpeedMbps: 100, Duplex: "half", BytesSentPerSec: 5e6, BytesRecvPerSec: 5e6}
+	if util := interfaceUtilization(half); math.Abs(util-80) > 1e-9 {
+		t.Fatalf("expected half duplex to sum directions to 80%%, got %.2f", util)
+	}
+}
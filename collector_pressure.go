@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PressureCollector Linux PSI（pressure stall information）采集器
+type PressureCollector struct {
+	root string
+}
+
+func NewPressureCollector(root string) *PressureCollector {
+	if root == "" {
+		root = "/proc/pressure"
+	}
+	return &PressureCollector{root: root}
+}
+
+func (c *PressureCollector) Name() string {
+	return "pressure"
+}
+
+// Collect 读取 cpu/memory/io 三类压力；内核未启用 PSI 时返回 supported=false
+func (c *PressureCollector) Collect() (interface{}, error) {
+	metrics := &PressureMetrics{}
+
+	cpu, err := parsePressureFile(filepath.Join(c.root, "cpu"))
+	if err != nil {
+		return metrics, nil
+	}
+	metrics.Supported = true
+	metrics.CPU = cpu
+	// memory/io 单独失败时保留零值，不影响其他资源
+	metrics.Memory, _ = parsePressureFile(filepath.Join(c.root, "memory"))
+	metrics.IO, _ = parsePressureFile(filepath.Join(c.root, "io"))
+	return metrics, nil
+}
+
+// parsePressureFile 解析 PSI 格式文件，/proc/pressure/* 和 cgroup 的 *.pressure 通用：
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePressureFile(path string) (PressureResource, error) {
+	var resource PressureResource
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return resource, err
+	}
+
+	found := false
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		var stat *PressureStat
+		switch fields[0] {
+		case "some":
+			stat = &resource.Some
+		case "full":
+			stat = &resource.Full
+		default:
+			continue
+		}
+		found = true
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "avg10":
+				stat.Avg10, _ = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stat.Avg60, _ = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stat.Avg300, _ = strconv.ParseFloat(value, 64)
+			case "total":
+				stat.TotalUsec, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+	}
+	if !found {
+		return resource, errors.New("no pressure data in " + path)
+	}
+	return resource, nil
+}
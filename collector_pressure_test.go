@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPressureCollectorReadsFixtures(t *testing.T) {
+	data, err := NewPressureCollector(filepath.Join("testdata", "pressure")).Collect()
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	metrics := data.(*PressureMetrics)
+	if !metrics.Supported {
+		t.Fatal("expected PSI to be supported")
+	}
+	if metrics.CPU.Some.Avg10 != 2.5 || metrics.CPU.Some.Avg60 != 1.75 || metrics.CPU.Some.TotalUsec != 123456789 {
+		t.Fatalf("unexpected cpu pressure: %#v", metrics.CPU.Some)
+	}
+	if metrics.Memory.Full.Avg10 != 4 || metrics.Memory.Full.TotalUsec != 45678 {
+		t.Fatalf("unexpected memory full pressure: %#v", metrics.Memory.Full)
+	}
+	if metrics.IO.Full.Avg300 != 8 || metrics.IO.Some.Avg10 != 30 {
+		t.Fatalf("unexpected io pressure: %#v", metrics.IO)
+	}
+}
+
+func TestPressureCollectorDegradesWithoutPSI(t *testing.T) {
+	data, err := NewPressureCollector(filepath.Join(t.TempDir(), "missing")).Collect()
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if data.(*PressureMetrics).Supported {
+		t.Fatal("expected supported=false when /proc/pressure is missing")
+	}
+}
+
+func TestParsePressureFileWithOnlySomeLine(t *testing.T) {
+	// 旧内核的 /proc/pressure/cpu 只有 some 行
+	path := filepath.Join(t.TempDir(), "cpu")
+	if err := os.WriteFile(path, []byte("some avg10=0.50 avg60=0.25 avg300=0.10 total=42\n"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	resource, err := parsePressureFile(path)
+	if err != nil {
+		t.Fatalf("parsePressureFile: %v", err)
+	}
+	if resource.Some.Avg10 != 0.5 || resource.Some.TotalUsec != 42 || resource.Full != (PressureStat{}) {
+		t.Fatalf("unexpected resource: %#v", resource)
+	}
+}
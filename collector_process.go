@@ -5,30 +5,134 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"math"
 	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/shirou/gopsutil/v3/host"
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/shirou/gopsutil/v3/process"
 )
 
 // ProcessCollector 进程监控收集器
+// 跨采集周期保留进程句柄和 CPU 时间，按两次采集之间的真实间隔计算 CPU 使用率，不再额外休眠
+// Linux 上每个周期对每个进程只读一次 /proc/<pid>/stat，创建时间、CPU 时间、进程名、RSS 和状态都取自这一次读取
 type ProcessCollector struct {
-	maxProcesses int // 最多收集的进程数
+	procRoot     string
+	bootTime     int64 // 开机时间（秒），用于把 stat 中的启动节拍换算为创建时间
+	pageSize     uint64
+	maxProcesses int    // 最多收集的进程数
+	sortBy       string // 排序字段：cpu、memory、io、fds、threads
+	watch        []*processMatcher
+	aggregate    bool // 是否按进程名、用户、容器/unit 汇总所有进程
+	workers      int  // 并发读取 /proc 的协程数
+	usernames    sync.Map
+	redactor     *Redactor
+	fds          *procFDTable
+	ownFDs       bool // 未与其他收集器共享时每次采集前自行重置
+	tracked      map[processKey]*trackedProcess
+	prevTime     time.Time
+	stats        []procStat      // 最近一次采样读取的 stat，供进程状态汇总复用
+	samples      []processSample // 最近一次采样的全部进程，供进程存活规则复用
 }
 
-// NewProcessCollector 创建进程收集器
-func NewProcessCollector(maxProcesses int) *ProcessCollector {
+// numFDs Linux 上取本周期 fd 表中的条目数，无权限读取的进程为0
+func (c *ProcessCollector) numFDs(sample *processSample) int {
+	if sample.hasStat {
+		return len(c.fds.snapshot()[sample.key.pid])
+	}
+	fds, err := sample.proc.NumFDs()
+	if err != nil {
+		return 0
+	}
+	return int(fds)
+}
+
+// processKey 用 PID 加创建时间标识进程，PID 复用时视为新进程
+type processKey struct {
+	pid        int32
+	createTime int64
+}
+
+type trackedProcess struct {
+	proc     *process.Process
+	cpuTotal float64 // 累计 user+system 秒数
+	ioTotal  uint64  // 累计读写字节数，仅按 io 排序时采集
+	io       processIOSnapshot
+	owner    *processAggregate // 上一周期的分组字段，仅开启 aggregate 时保存
+}
+
+// processIOSnapshot 进程上一次被上报时的读写字节数，只对上报的进程读取，仅相邻两个周期都上报时计算速率
+type processIOSnapshot struct {
+	readBytes  uint64
+	writeBytes uint64
+	time       time.Time
+}
+
+// processSample 单个进程一次采样的结果
+type processSample struct {
+	key        processKey
+	proc       *process.Process
+	cpuTotal   float64
+	cpuPercent float64
+	ioTotal    uint64
+	rank       float64 // 排序字段的值
+	watched    bool
+	stat       procStat // 非 Linux 系统上为零值
+	hasStat    bool
+	io         processIOSnapshot
+	aggregate  processAggregate
+}
+
+var processSortKeys = map[string]bool{"cpu": true, "memory": true, "io": true, "fds": true, "threads": true}
+
+// NewProcessCollector 创建进程收集器，fds 为 nil 时使用独立的 fd 表
+func NewProcessCollector(config ProcessConfig, redactor *Redactor, fds *procFDTable) *ProcessCollector {
+	maxProcesses := config.MaxCount
 	if maxProcesses <= 0 {
 		maxProcesses = 50 // 默认最多50个进程
 	}
-	return &ProcessCollector{
+	sortBy := strings.ToLower(config.SortBy)
+	if !processSortKeys[sortBy] {
+		if sortBy != "" {
+			log.Printf("Unknown process sort_by %q, falling back to cpu", config.SortBy)
+		}
+		sortBy = "cpu"
+	}
+	workers := runtime.NumCPU()
+	if workers > 8 {
+		workers = 8
+	}
+	bootTime, err := host.BootTime()
+	if err != nil {
+		log.Printf("Warning: Failed to get boot time: %v", err)
+	}
+	c := &ProcessCollector{
+		procRoot:     "/proc",
+		bootTime:     int64(bootTime),
+		pageSize:     uint64(os.Getpagesize()),
 		maxProcesses: maxProcesses,
+		sortBy:       sortBy,
+		watch:        compileProcessMatchers(config.Watch),
+		aggregate:    config.Aggregate,
+		redactor:     redactor,
+		fds:          fds,
+		workers:      workers,
+		tracked:      make(map[processKey]*trackedProcess),
+	}
+	if c.fds == nil {
+		c.fds, c.ownFDs = newProcFDTable(c.procRoot), true
 	}
+	return c
 }
 
 // Name 返回采集器名称
@@ -38,14 +142,15 @@ func (c *ProcessCollector) Name() string {
 
 // Collect 采集进程信息
 func (c *ProcessCollector) Collect() (interface{}, error) {
-	processes, err := process.Processes()
+	if c.ownFDs {
+		c.fds.reset()
+	}
+	pids, err := process.Pids()
 	if err != nil {
 		log.Printf("Failed to get process list: %v", err)
 		return nil, err
 	}
 
-	log.Printf("Found %d total processes, collecting top %d by CPU usage", len(processes), c.maxProcesses)
-
 	// 获取系统总内存，用于计算内存百分比
 	vmStat, err := mem.VirtualMemory()
 	totalMemory := uint64(0)
@@ -55,111 +160,310 @@ func (c *ProcessCollector) Collect() (interface{}, error) {
 		log.Printf("Warning: Failed to get total memory: %v", err)
 	}
 
-	// 为了避免系统进程太多时速度太慢，设置一个合理的上限
-	// 但为了确保能找到高CPU进程（包括新创建的进程），上限应该足够大
-	// 如果进程总数较少，检查所有进程；如果进程总数较多，检查前2000个
-	maxCheckProcesses := 2000
-	if len(processes) < maxCheckProcesses {
-		maxCheckProcesses = len(processes)
+	now := time.Now()
+	all := c.sample(pids, now)
+	var groups []ProcessGroup
+	if c.aggregate {
+		groups = buildProcessGroups(all, c.maxProcesses)
 	}
+	samples := c.selectSamples(all)
 
-	// 第一步：初始化进程的CPU统计（用于准确计算CPU使用率）
-	// 第一次调用CPUPercent来初始化内部状态
-	log.Printf("Initializing CPU stats for %d processes (out of %d total)...", maxCheckProcesses, len(processes))
-	initCount := 0
-	for i := 0; i < maxCheckProcesses; i++ {
-		p := processes[i]
-		_, err := p.CPUPercent()
-		if err == nil {
-			initCount++
+	var processList []ProcessInfo
+	errorCount := 0
+	for _, sample := range samples {
+		info, err := c.getProcessInfo(sample, totalMemory)
+		if err != nil {
+			errorCount++
+			continue // 跳过采样后已退出的进程
 		}
+		info.CPUPercent = sample.cpuPercent
+		info.Watched = sample.watched
+		c.applyIORates(info, sample, now)
+		processList = append(processList, *info)
 	}
-	log.Printf("Initialized CPU stats for %d processes", initCount)
 
-	// 等待1秒，让CPU统计有时间累积（与top命令类似，需要时间间隔来准确计算）
-	time.Sleep(1 * time.Second)
+	log.Printf("Collected %d of %d processes (sorted by %s), %d errors", len(processList), len(pids), c.sortBy, errorCount)
 
-	// 第二步：获取所有检查范围内进程的CPU使用率（经过1秒等待后的准确值）
-	type processCPU struct {
-		proc *process.Process
-		cpu  float64
+	return &ProcessMetrics{
+		Processes: processList,
+		Groups:    groups,
+		Total:     len(pids),
+		Collected: len(processList),
+	}, nil
+}
+
+// selectSamples 按排序字段取前 maxProcesses 个，再追加未进入 Top 的关注进程
+func (c *ProcessCollector) selectSamples(samples []processSample) []processSample {
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].rank > samples[j].rank
+	})
+	if len(samples) <= c.maxProcesses {
+		return samples
+	}
+	selected := samples[:c.maxProcesses:c.maxProcesses]
+	for _, sample := range samples[c.maxProcesses:] {
+		if sample.watched {
+			selected = append(selected, sample)
+		}
 	}
-	var processCPUList []processCPU
+	return selected
+}
 
-	log.Printf("Collecting CPU usage for %d processes...", maxCheckProcesses)
-	collectedCount := 0
-	maxCPU := 0.0
-	for i := 0; i < maxCheckProcesses; i++ {
-		p := processes[i]
-		cpuPercent, err := p.CPUPercent()
-		if err != nil {
+// sample 并发读取所有进程的 CPU 时间，与上一周期的句柄比较得到 CPU 使用率
+// 已退出的进程不再保留句柄
+func (c *ProcessCollector) sample(pids []int32, now time.Time) []processSample {
+	elapsed := now.Sub(c.prevTime).Seconds()
+	results := make([]*processSample, len(pids))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < c.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = c.sampleProcess(pids[i], now, elapsed)
+			}
+		}()
+	}
+	for i := range pids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	samples := make([]processSample, 0, len(pids))
+	tracked := make(map[processKey]*trackedProcess, len(pids))
+	var stats []procStat
+	for _, result := range results {
+		if result == nil {
 			continue
 		}
+		samples = append(samples, *result)
+		if result.hasStat {
+			stats = append(stats, result.stat)
+		}
+		entry := &trackedProcess{proc: result.proc, cpuTotal: result.cpuTotal, ioTotal: result.ioTotal, io: result.io}
+		if c.aggregate {
+			entry.owner = &result.aggregate
+		}
+		tracked[result.key] = entry
+	}
+	c.tracked = tracked
+	c.prevTime = now
+	c.stats = stats
+	c.samples = samples
+	return samples
+}
+
+// takeProcStats 取走最近一次采样读取的 stat，同一次采样的结果只返回一次，之后返回 nil
+func (c *ProcessCollector) takeProcStats() []procStat {
+	stats := c.stats
+	c.stats = nil
+	return stats
+}
 
-		// 收集所有进程用于排序（包括CPU为0的，但会按CPU使用率排序）
-		processCPUList = append(processCPUList, processCPU{
-			proc: p,
-			cpu:  cpuPercent,
-		})
-		collectedCount++
+// takeProcessSamples 取走最近一次采样的全部进程，同一次采样的结果只返回一次，之后返回 nil
+func (c *ProcessCollector) takeProcessSamples() []processSample {
+	samples := c.samples
+	c.samples = nil
+	return samples
+}
 
-		// 记录最大CPU使用率（用于日志输出）
-		if cpuPercent > maxCPU {
-			maxCPU = cpuPercent
+// sampleProcess 只读取上一周期的 tracked，可在多个协程中并发调用
+// 已跟踪的进程沿用上一周期的句柄，只有新进程才创建句柄
+func (c *ProcessCollector) sampleProcess(pid int32, now time.Time, elapsed float64) *processSample {
+	sample := &processSample{}
+	sample.stat, sample.hasStat = readProcStat(c.procRoot, strconv.FormatInt(int64(pid), 10))
+
+	var proc *process.Process
+	var createTime int64
+	if sample.hasStat {
+		createTime = c.bootTime*1000 + sample.stat.startTime*1000/procClockTicks
+		sample.cpuTotal = float64(sample.stat.utime+sample.stat.stime) / procClockTicks
+	} else {
+		var err error
+		if proc, err = process.NewProcess(pid); err != nil {
+			return nil
+		}
+		if createTime, err = proc.CreateTime(); err != nil {
+			return nil
+		}
+	}
+	sample.key = processKey{pid: pid, createTime: createTime}
+
+	prev, known := c.tracked[sample.key]
+	switch {
+	case known:
+		proc = prev.proc
+	case proc == nil:
+		var err error
+		if proc, err = process.NewProcess(pid); err != nil {
+			return nil
 		}
 	}
-	log.Printf("Collected CPU usage for %d processes (max CPU: %.2f%%)", collectedCount, maxCPU)
+	sample.proc = proc
+	if !sample.hasStat {
+		times, err := proc.Times()
+		if err != nil {
+			return nil
+		}
+		sample.cpuTotal = times.User + times.System
+	}
+	// 快照只在上一周期被上报时才有效，中间未上报过的进程按新进程处理，避免跨多个周期求平均
+	if known && prev.io.time.Equal(c.prevTime) {
+		sample.io = prev.io
+	}
 
-	// 第三步：按CPU使用率降序排序（使用Go标准库的快速排序）
-	sort.Slice(processCPUList, func(i, j int) bool {
-		return processCPUList[i].cpu > processCPUList[j].cpu
-	})
+	switch {
+	case known && elapsed > 0:
+		sample.cpuPercent = processCPUPercent(prev.cpuTotal, sample.cpuTotal, elapsed)
+	case !c.prevTime.IsZero() && time.UnixMilli(createTime).After(c.prevTime):
+		// 上一周期之后才启动的进程，用存活期间的平均值，保证新起的高CPU进程也能排进Top
+		// 存活不足1秒时按1秒计，避免时钟节拍精度导致的虚高
+		lifetime := math.Max(now.Sub(time.UnixMilli(createTime)).Seconds(), 1)
+		sample.cpuPercent = processCPUPercent(0, sample.cpuTotal, lifetime)
+	}
 
-	// 输出排序后的前5个进程的CPU使用率（用于调试）
-	if len(processCPUList) > 0 {
-		log.Printf("Top 5 processes by CPU usage:")
-		for i := 0; i < 5 && i < len(processCPUList); i++ {
-			pid := processCPUList[i].proc.Pid
-			name, _ := processCPUList[i].proc.Name()
-			log.Printf("  #%d: PID=%d, Name=%s, CPU=%.2f%%", i+1, pid, name, processCPUList[i].cpu)
+	// 只读取排序需要的字段，避免每个周期对所有进程做多余的 /proc 读取
+	switch c.sortBy {
+	case "memory":
+		if sample.hasStat {
+			sample.rank = float64(sample.stat.rssPages * c.pageSize)
+		} else if memInfo, err := proc.MemoryInfo(); err == nil {
+			sample.rank = float64(memInfo.RSS)
+		}
+	case "io":
+		// 其他用户进程的 /proc/<pid>/io 需要 root 权限，读取失败时按0排序
+		if io, err := proc.IOCounters(); err == nil {
+			sample.ioTotal = io.ReadBytes + io.WriteBytes
+			if known && elapsed > 0 {
+				sample.rank = counterRate(prev.ioTotal, sample.ioTotal, elapsed)
+			}
 		}
+	case "fds":
+		sample.rank = float64(c.numFDs(sample))
+	case "threads":
+		if threads, err := proc.NumThreads(); err == nil {
+			sample.rank = float64(threads)
+		}
+	default:
+		sample.rank = sample.cpuPercent
 	}
 
-	// 第四步：只取前maxProcesses个CPU使用率最高的进程
-	if len(processCPUList) > c.maxProcesses {
-		processCPUList = processCPUList[:c.maxProcesses]
+	for _, matcher := range c.watch {
+		if matcher.match(proc) {
+			sample.watched = true
+			break
+		}
 	}
+	if c.aggregate {
+		var owner *processAggregate
+		if known {
+			owner = prev.owner
+		}
+		sample.aggregate = c.aggregateFields(sample, owner)
+	}
+	return sample
+}
 
-	// 第五步：获取每个进程的详细信息
-	var processList []ProcessInfo
-	errorCount := 0
+// processMatcher 按进程名、命令行正则和用户匹配进程，配置的条件需全部满足
+type processMatcher struct {
+	name    string
+	cmdline *regexp.Regexp
+	user    string
+}
 
-	log.Printf("Getting detailed info for top %d processes...", len(processCPUList))
-	for _, pc := range processCPUList {
-		info, err := c.getProcessInfo(pc.proc, totalMemory)
+func compileProcessMatchers(configs []ProcessMatcherConfig) []*processMatcher {
+	matchers := make([]*processMatcher, 0, len(configs))
+	for _, config := range configs {
+		matcher, err := newProcessMatcher(config)
 		if err != nil {
-			errorCount++
-			log.Printf("Failed to get info for process: %v", err)
-			continue // 跳过无法获取信息的进程
+			log.Printf("Ignoring process matcher %+v: %v", config, err)
+			continue
 		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers
+}
 
-		// 使用之前计算的CPU使用率（更准确）
-		info.CPUPercent = pc.cpu
+func newProcessMatcher(config ProcessMatcherConfig) (*processMatcher, error) {
+	if config.Name == "" && config.Cmdline == "" && config.User == "" {
+		return nil, fmt.Errorf("at least one of name, cmdline or user is required")
+	}
+	matcher := &processMatcher{name: config.Name, user: config.User}
+	if config.Cmdline != "" {
+		re, err := regexp.Compile(config.Cmdline)
+		if err != nil {
+			return nil, err
+		}
+		matcher.cmdline = re
+	}
+	return matcher, nil
+}
 
-		processList = append(processList, *info)
+// match 按开销从低到高依次比较，任一条件不满足立即返回
+func (m *processMatcher) match(proc *process.Process) bool {
+	if m.name != "" {
+		if name, err := proc.Name(); err != nil || name != m.name {
+			return false
+		}
+	}
+	if m.cmdline != nil {
+		if cmdline, err := proc.Cmdline(); err != nil || !m.cmdline.MatchString(cmdline) {
+			return false
+		}
+	}
+	if m.user != "" {
+		if username, err := proc.Username(); err != nil || username != m.user {
+			return false
+		}
 	}
+	return true
+}
 
-	log.Printf("Collected %d processes, %d errors", len(processList), errorCount)
+// matchIdentity 与 match 相同，但使用调用方已读取的进程名、命令行和用户名
+func (m *processMatcher) matchIdentity(id *processIdentity) bool {
+	if m.name != "" && id.name != m.name {
+		return false
+	}
+	if m.cmdline != nil && !m.cmdline.MatchString(id.cmdline) {
+		return false
+	}
+	if m.user != "" && id.user != m.user {
+		return false
+	}
+	return true
+}
 
-	return &ProcessMetrics{
-		Processes: processList,
-		Total:     len(processes),
-		Collected: len(processList),
-	}, nil
+// processCPUPercent 与 top 一致，单核满载为100%，多线程进程可超过100%
+func processCPUPercent(prevTotal, curTotal, seconds float64) float64 {
+	if seconds <= 0 || curTotal < prevTotal {
+		return 0
+	}
+	return (curTotal - prevTotal) / seconds * 100
 }
 
-// getProcessInfo 获取单个进程的详细信息
-func (c *ProcessCollector) getProcessInfo(p *process.Process, totalMemory uint64) (*ProcessInfo, error) {
+// applyIORates 用上一周期的读写字节数计算速率，并更新快照
+// 读取其他用户进程的 /proc/<pid>/io 需要 root 权限，失败时速率为0
+func (c *ProcessCollector) applyIORates(info *ProcessInfo, sample processSample, now time.Time) {
+	io, err := sample.proc.IOCounters()
+	if err != nil {
+		return
+	}
+	if prev := sample.io; !prev.time.IsZero() {
+		elapsed := now.Sub(prev.time).Seconds()
+		info.ReadBytesPerSec = counterRate(prev.readBytes, io.ReadBytes, elapsed)
+		info.WriteBytesPerSec = counterRate(prev.writeBytes, io.WriteBytes, elapsed)
+	}
+	if tracked, ok := c.tracked[sample.key]; ok {
+		tracked.io = processIOSnapshot{readBytes: io.ReadBytes, writeBytes: io.WriteBytes, time: now}
+	}
+}
+
+// getProcessInfo 获取单个进程的详细信息，状态沿用采样时读取的 stat
+func (c *ProcessCollector) getProcessInfo(sample processSample, totalMemory uint64) (*ProcessInfo, error) {
+	p := sample.proc
 	name, err := p.Name()
 	if err != nil {
 		return nil, err
@@ -192,8 +496,10 @@ func (c *ProcessCollector) getProcessInfo(p *process.Process, totalMemory uint64
 	}
 
 	createTime, _ := p.CreateTime()
-	statusStr := c.getTopCompatibleStatus(p)
+	statusStr := c.getTopCompatibleStatus(sample)
 	cmdline, _ := p.Cmdline()
+	// 先脱敏再截断，避免截断把密码参数拆开导致规则无法命中
+	cmdline = c.redactor.Redact(cmdline)
 
 	// 限制命令长度
 	if len(cmdline) > 200 {
@@ -201,7 +507,7 @@ func (c *ProcessCollector) getProcessInfo(p *process.Process, totalMemory uint64
 	}
 
 	// CPU使用率将在Collect中通过时间间隔计算，这里先设为0
-	return &ProcessInfo{
+	info := &ProcessInfo{
 		PID:           int32(p.Pid),
 		Name:          name,
 		User:          username,
@@ -211,31 +517,74 @@ func (c *ProcessCollector) getProcessInfo(p *process.Process, totalMemory uint64
 		CreateTime:    createTime / 1000, // 转换为秒
 		Status:        statusStr,
 		Command:       cmdline,
-	}, nil
-}
-
-func (c *ProcessCollector) getTopCompatibleStatus(p *process.Process) string {
-	if status := readLinuxProcessState(p.Pid); status != "" {
-		return status
 	}
 
-	statusSlice, _ := p.Status()
-	if len(statusSlice) == 0 {
-		return ""
+	// 扩展信息读取失败时保持零值，不影响进程上报
+	info.PPID, _ = p.Ppid()
+	info.NumThreads, _ = p.NumThreads()
+	info.NumFDs, _ = p.NumFDs()
+	info.Nice, _ = p.Nice()
+	if ctxSwitches, err := p.NumCtxSwitches(); err == nil {
+		info.VoluntaryCtxSwitches = ctxSwitches.Voluntary
+		info.InvoluntaryCtxSwitches = ctxSwitches.Involuntary
 	}
-	return normalizeProcessStatus(statusSlice[0])
+	info.CgroupPath = readProcessCgroup(c.procRoot, p.Pid)
+	info.ContainerID, info.Unit = processCgroupWorkload(info.CgroupPath)
+	return info, nil
 }
 
-func readLinuxProcessState(pid int32) string {
-	data, err := os.ReadFile("/proc/" + strconv.FormatInt(int64(pid), 10) + "/stat")
+// readProcessCgroup 读取 /proc/<pid>/cgroup，优先 cgroup v2 统一层级的路径
+// 混合模式下 v2 路径通常为 "/"，此时退回 systemd 层级，再退回 memory 控制器
+func readProcessCgroup(procRoot string, pid int32) string {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.FormatInt(int64(pid), 10), "cgroup"))
 	if err != nil {
 		return ""
 	}
-	fields := strings.Fields(string(data))
-	if len(fields) < 3 || len(fields[2]) != 1 {
+	paths := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			paths["unified"] = parts[2]
+			continue
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			paths[controller] = parts[2]
+		}
+	}
+	for _, key := range []string{"unified", "name=systemd", "memory"} {
+		if path, ok := paths[key]; ok && path != "/" {
+			return path
+		}
+	}
+	return paths["unified"]
+}
+
+// processCgroupWorkload 从进程所在 cgroup 向上查找容器或 systemd unit
+// 容器内进程可能位于 scope 下的子 cgroup（如 .../docker-<id>.scope/init）
+func processCgroupWorkload(path string) (containerID, unit string) {
+	for path != "" && path != "/" {
+		if _, id, serviceUnit := cgroupWorkload(path); id != "" || serviceUnit != "" {
+			return id, serviceUnit
+		}
+		path = path[:strings.LastIndex(path, "/")]
+	}
+	return "", ""
+}
+
+// getTopCompatibleStatus Linux 上直接使用 stat 中的单字母状态，其他系统由 gopsutil 状态转换
+func (c *ProcessCollector) getTopCompatibleStatus(sample processSample) string {
+	if sample.hasStat && len(sample.stat.state) == 1 {
+		return sample.stat.state
+	}
+
+	statusSlice, _ := sample.proc.Status()
+	if len(statusSlice) == 0 {
 		return ""
 	}
-	return fields[2]
+	return normalizeProcessStatus(statusSlice[0])
 }
 
 func normalizeProcessStatus(status string) string {
@@ -271,11 +620,35 @@ type ProcessInfo struct {
 	CreateTime    int64   `json:"create_time"`
 	Status        string  `json:"status"`
 	Command       string  `json:"command"`
+	Watched       bool    `json:"watched"` // 命中关注列表，不论排名都会上报
+
+	PPID                   int32   `json:"ppid"`
+	NumThreads             int32   `json:"num_threads"`
+	NumFDs                 int32   `json:"num_fds"`
+	ReadBytesPerSec        float64 `json:"read_bytes_per_sec"`
+	WriteBytesPerSec       float64 `json:"write_bytes_per_sec"`
+	VoluntaryCtxSwitches   int64   `json:"voluntary_ctx_switches"`
+	InvoluntaryCtxSwitches int64   `json:"involuntary_ctx_switches"`
+	Nice                   int32   `json:"nice"`
+	CgroupPath             string  `json:"cgroup_path"`
+	ContainerID            string  `json:"container_id"` // 由 cgroup 路径解析，非容器进程为空
+	Unit                   string  `json:"unit"`         // systemd service unit
 }
 
 // ProcessMetrics 进程指标
 type ProcessMetrics struct {
-	Processes []ProcessInfo `json:"processes"`
-	Total     int           `json:"total"`
-	Collected int           `json:"collected"`
+	Processes []ProcessInfo  `json:"processes"`
+	Groups    []ProcessGroup `json:"groups"` // 覆盖所有进程的分组汇总
+	Total     int            `json:"total"`
+	Collected int            `json:"collected"`
+}
+
+// ProcessGroup 进程分组汇总，By 为 name、user 或 workload（容器ID或 systemd unit）
+type ProcessGroup struct {
+	By          string  `json:"by"`
+	Key         string  `json:"key"`
+	Count       int     `json:"count"`
+	CPUPercent  float64 `json:"cpu_percent"`
+	MemoryBytes uint64  `json:"memory_bytes"` // RSS 合计
+	NumFDs      int64   `json:"num_fds"`
 }
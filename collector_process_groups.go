@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// processAggregate 聚合统计所需的进程字段，所有进程都会读取
+// 进程名和 RSS 取自本周期已读取的 stat，FD 数取自本周期共用的 fd 表
+// UID 每个周期重新读取，进程 setuid 后重新解析用户名；容器/unit 在进程存活期间只读取一次
+type processAggregate struct {
+	name     string
+	uid      int32
+	hasUID   bool
+	user     string
+	workload string // 容器ID或 systemd unit，宿主机上的普通进程为空
+	rss      uint64
+	fds      int
+}
+
+// 进程分组维度
+const (
+	processGroupByName     = "name"
+	processGroupByUser     = "user"
+	processGroupByWorkload = "workload"
+)
+
+// aggregateFields 读取分组字段，失败的字段保持零值
+// prev 为上一周期同一进程的字段，非空时沿用其中的容器/unit，UID 未变化时沿用用户名
+func (c *ProcessCollector) aggregateFields(sample *processSample, prev *processAggregate) processAggregate {
+	var fields processAggregate
+	if sample.hasStat {
+		fields.name = sample.stat.name
+		fields.rss = sample.stat.rssPages * c.pageSize
+	} else {
+		fields.name, _ = sample.proc.Name()
+		if memInfo, err := sample.proc.MemoryInfo(); err == nil {
+			fields.rss = memInfo.RSS
+		}
+	}
+	fields.fds = c.numFDs(sample)
+
+	if sample.hasStat {
+		fields.uid, fields.hasUID = readProcUID(c.procRoot, sample.key.pid)
+	} else if uids, err := sample.proc.Uids(); err == nil && len(uids) > 0 {
+		fields.uid, fields.hasUID = uids[0], true
+	}
+	switch {
+	case prev != nil && prev.hasUID == fields.hasUID && prev.uid == fields.uid:
+		fields.user = prev.user
+	case fields.hasUID:
+		fields.user = cachedUsername(&c.usernames, fields.uid)
+	}
+
+	if prev != nil {
+		fields.workload = prev.workload
+		return fields
+	}
+	containerID, unit := processCgroupWorkload(readProcessCgroup(c.procRoot, sample.key.pid))
+	fields.workload = containerID
+	if fields.workload == "" {
+		fields.workload = unit
+	}
+	return fields
+}
+
+// readProcUID 读取 /proc/<pid>/status 中 Uid 行的真实 UID
+func readProcUID(procRoot string, pid int32) (int32, bool) {
+	file, err := os.Open(filepath.Join(procRoot, strconv.FormatInt(int64(pid), 10), "status"))
+	if err != nil {
+		return 0, false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "Uid:" {
+			continue
+		}
+		uid, err := strconv.ParseInt(fields[1], 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int32(uid), true
+	}
+	return 0, false
+}
+
+// cachedUsername 缓存 UID 到用户名的映射，避免每个进程都解析一次 /etc/passwd
+// 没有对应用户时返回数字 UID
+func cachedUsername(cache *sync.Map, uid int32) string {
+	if cached, ok := cache.Load(uid); ok {
+		return cached.(string)
+	}
+	name := strconv.FormatInt(int64(uid), 10)
+	if u, err := user.LookupId(name); err == nil {
+		name = u.Username
+	}
+	cache.Store(uid, name)
+	return name
+}
+
+// buildProcessGroups 按进程名、用户、容器/unit 分组汇总，每个维度按CPU降序取前 limit 个
+func buildProcessGroups(samples []processSample, limit int) []ProcessGroup {
+	buckets := map[string]map[string]*ProcessGroup{
+		processGroupByName:     {},
+		processGroupByUser:     {},
+		processGroupByWorkload: {},
+	}
+	add := func(by, key string, sample processSample) {
+		if key == "" {
+			return
+		}
+		group, ok := buckets[by][key]
+		if !ok {
+			group = &ProcessGroup{By: by, Key: key}
+			buckets[by][key] = group
+		}
+		group.Count++
+		group.CPUPercent += sample.cpuPercent
+		group.MemoryBytes += sample.aggregate.rss
+		group.NumFDs += int64(sample.aggregate.fds)
+	}
+	for _, sample := range samples {
+		add(processGroupByName, sample.aggregate.name, sample)
+		add(processGroupByUser, sample.aggregate.user, sample)
+		add(processGroupByWorkload, sample.aggregate.workload, sample)
+	}
+
+	groups := make([]ProcessGroup, 0)
+	for _, by := range []string{processGroupByName, processGroupByUser, processGroupByWorkload} {
+		dimension := make([]ProcessGroup, 0, len(buckets[by]))
+		for _, group := range buckets[by] {
+			dimension = append(dimension, *group)
+		}
+		sort.Slice(dimension, func(i, j int) bool {
+			if dimension[i].CPUPercent != dimension[j].CPUPercent {
+				return dimension[i].CPUPercent > dimension[j].CPUPercent
+			}
+			if dimension[i].MemoryBytes != dimension[j].MemoryBytes {
+				return dimension[i].MemoryBytes > dimension[j].MemoryBytes
+			}
+			return dimension[i].Key < dimension[j].Key
+		})
+		if len(dimension) > limit {
+			dimension = dimension[:limit]
+		}
+		groups = append(groups, dimension...)
+	}
+	return groups
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+const groupsTestContainerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestBuildProcessGroupsAggregatesByDimension(t *testing.T) {
+	samples := []processSample{
+		{cpuPercent: 10, aggregate: processAggregate{name: "nginx", user: "www-data", workload: "nginx.service", rss: 100, fds: 12}},
+		{cpuPercent: 15, aggregate: processAggregate{name: "nginx", user: "www-data", workload: "nginx.service", rss: 200, fds: 18}},
+		{cpuPercent: 50, aggregate: processAggregate{name: "python", user: "app", workload: groupsTestContainerID, rss: 1000, fds: 40}},
+		{cpuPercent: 1, aggregate: processAggregate{name: "bash", user: "app", rss: 10, fds: 4}},
+	}
+
+	groups := buildProcessGroups(samples, 10)
+
+	byKey := make(map[string]ProcessGroup)
+	for _, group := range groups {
+		byKey[group.By+"/"+group.Key] = group
+	}
+	if len(byKey) != 7 {
+		t.Fatalf("expected 3 names, 2 users and 2 workloads, got %#v", groups)
+	}
+	nginx := byKey["name/nginx"]
+	if nginx.Count != 2 || nginx.CPUPercent != 25 || nginx.MemoryBytes != 300 || nginx.NumFDs != 30 {
+		t.Fatalf("unexpected nginx group: %#v", nginx)
+	}
+	app := byKey["user/app"]
+	if app.Count != 2 || app.CPUPercent != 51 || app.MemoryBytes != 1010 || app.NumFDs != 44 {
+		t.Fatalf("unexpected app user group: %#v", app)
+	}
+	if _, ok := byKey["workload/"]; ok {
+		t.Fatal("expected processes outside containers and units to be left out of workload groups")
+	}
+	if groups[0].By != processGroupByName || groups[0].Key != "python" {
+		t.Fatalf("expected groups sorted by cpu within each dimension, got first %#v", groups[0])
+	}
+
+	if limited := buildProcessGroups(samples, 1); len(limited) != 3 {
+		t.Fatalf("expected one group per dimension with limit 1, got %d", len(limited))
+	}
+}
+
+func TestProcessCollectorReusesAggregateOwner(t *testing.T) {
+	collector := NewProcessCollector(ProcessConfig{MaxCount: 10, Aggregate: true}, nil, nil)
+	pid := int32(os.Getpid())
+	start := time.Now()
+
+	first := collector.sample([]int32{pid}, start)
+	if len(first) != 1 || first[0].aggregate.name == "" || first[0].aggregate.rss == 0 {
+		t.Fatalf("expected name and rss for the current process, got %#v", first)
+	}
+
+	// UID 未变化时沿用上一周期的用户名，容器/unit 不再重新读取
+	uid := int32(os.Getuid())
+	collector.tracked[first[0].key].owner = &processAggregate{uid: uid, hasUID: true, user: "cached", workload: "cached.service"}
+	second := collector.sample([]int32{pid}, start.Add(time.Second))
+	if second[0].aggregate.user != "cached" || second[0].aggregate.workload != "cached.service" {
+		t.Fatalf("expected cached owner fields, got %#v", second[0].aggregate)
+	}
+
+	// setuid 之后 UID 与缓存不同，重新解析用户名
+	collector.tracked[first[0].key].owner = &processAggregate{uid: uid + 1, hasUID: true, user: "cached", workload: "cached.service"}
+	third := collector.sample([]int32{pid}, start.Add(2*time.Second))
+	if want := cachedUsername(&collector.usernames, uid); third[0].aggregate.user != want || third[0].aggregate.workload != "cached.service" {
+		t.Fatalf("expected user %q after uid change, got %#v", want, third[0].aggregate)
+	}
+	if second[0].aggregate.rss == 0 || second[0].aggregate.fds == 0 {
+		t.Fatalf("expected rss and fds to be refreshed every tick, got %#v", second[0].aggregate)
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ProcessRuleCollector 进程存活规则检测：按规则统计匹配的进程实例数，
+// 检查缺失、实例过多或过少，以及 PID/创建时间变化导致的重启，结果按服务状态上报
+// 复用进程收集器本周期的采样结果，不再单独枚举进程
+type ProcessRuleCollector struct {
+	procRoot    string
+	rules       []*processRule
+	source      processSampleSource
+	needName    bool
+	needCmdline bool
+	needUser    bool
+	identities  map[processKey]*processIdentity
+	usernames   sync.Map
+}
+
+// processSampleSource 提供进程收集器本周期采样的全部进程
+type processSampleSource interface {
+	takeProcessSamples() []processSample
+}
+
+// processIdentity 规则匹配用到的进程字段，只读取规则需要的字段
+// 进程名和命令行在进程存活期间只读取一次；UID 每个周期重新读取，变化时重新解析用户名
+type processIdentity struct {
+	name    string
+	cmdline string
+	uid     int32
+	hasUID  bool
+	user    string
+}
+
+type processRule struct {
+	name    string
+	matcher *processMatcher
+	min     int
+	max     int // 0 表示不限制
+	prev    map[processKey]bool
+	started bool
+}
+
+// 进程规则状态，按严重程度排列
+const (
+	processRuleRunning   = "running"
+	processRuleRestarted = "restarted"
+	processRuleTooFew    = "too_few"
+	processRuleTooMany   = "too_many"
+	processRuleMissing   = "missing"
+)
+
+// processRuleServiceStatus 规则状态映射到服务状态已有的取值，具体情况写在描述中
+var processRuleServiceStatus = map[string]string{
+	processRuleRunning:   "running",
+	processRuleRestarted: "running",
+	processRuleTooFew:    "failed",
+	processRuleTooMany:   "failed",
+	processRuleMissing:   "stopped",
+}
+
+// NewProcessRuleCollector source 为 nil 或本周期没有采样结果时自行枚举进程
+func NewProcessRuleCollector(configs []ProcessRuleConfig, source processSampleSource) *ProcessRuleCollector {
+	collector := &ProcessRuleCollector{procRoot: "/proc", source: source}
+	for _, config := range configs {
+		matcher, err := newProcessMatcher(config.Match)
+		if err != nil {
+			log.Printf("Ignoring process rule %q: %v", config.Name, err)
+			continue
+		}
+		rule := &processRule{name: config.Name, matcher: matcher, min: config.Min, max: config.Max}
+		if config.Count > 0 {
+			rule.min, rule.max = config.Count, config.Count
+		}
+		if rule.min <= 0 {
+			rule.min = 1
+		}
+		if rule.name == "" {
+			rule.name = fmt.Sprintf("%s%s%s", config.Match.Name, config.Match.Cmdline, config.Match.User)
+		}
+		collector.rules = append(collector.rules, rule)
+		collector.needName = collector.needName || matcher.name != ""
+		collector.needCmdline = collector.needCmdline || matcher.cmdline != nil
+		collector.needUser = collector.needUser || matcher.user != ""
+	}
+	return collector
+}
+
+func (c *ProcessRuleCollector) Name() string {
+	return "process_rules"
+}
+
+func (c *ProcessRuleCollector) Collect() (interface{}, error) {
+	if len(c.rules) == 0 {
+		return &ServiceMetrics{Services: []ServiceInfo{}}, nil
+	}
+	var samples []processSample
+	if c.source != nil {
+		samples = c.source.takeProcessSamples()
+	}
+	if samples == nil {
+		processes, err := process.Processes()
+		if err != nil {
+			return nil, err
+		}
+		samples = make([]processSample, 0, len(processes))
+		for _, proc := range processes {
+			createTime, err := proc.CreateTime()
+			if err != nil {
+				continue
+			}
+			samples = append(samples, processSample{key: processKey{pid: proc.Pid, createTime: createTime}, proc: proc})
+		}
+	}
+	return c.collectFrom(samples, time.Now()), nil
+}
+
+func (c *ProcessRuleCollector) collectFrom(samples []processSample, now time.Time) *ServiceMetrics {
+	identities := make(map[processKey]*processIdentity, len(samples))
+	for _, sample := range samples {
+		identities[sample.key] = c.identify(sample, c.identities[sample.key])
+	}
+	c.identities = identities
+
+	services := make([]ServiceInfo, 0, len(c.rules))
+	for _, rule := range c.rules {
+		current := make(map[processKey]bool)
+		var pids []int32
+		var youngest int64
+		for _, sample := range samples {
+			if !rule.matcher.matchIdentity(identities[sample.key]) {
+				continue
+			}
+			current[sample.key] = true
+			pids = append(pids, sample.key.pid)
+			if sample.key.createTime > youngest {
+				youngest = sample.key.createTime
+			}
+		}
+		sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+
+		status, description := rule.evaluate(current)
+		info := ServiceInfo{
+			Name:        rule.name,
+			Status:      processRuleServiceStatus[status],
+			Enabled:     true,
+			Description: description,
+			Source:      "process_rule",
+			Instances:   len(current),
+			PIDs:        pids,
+		}
+		if youngest > 0 {
+			info.Uptime = now.Unix() - youngest/1000
+		}
+		services = append(services, info)
+	}
+	return &ServiceMetrics{Services: services, Count: len(services)}
+}
+
+// identify 读取规则需要的进程字段，prev 为上一周期同一进程的字段
+func (c *ProcessRuleCollector) identify(sample processSample, prev *processIdentity) *processIdentity {
+	id := &processIdentity{}
+	if prev != nil {
+		*id = *prev
+	} else {
+		if c.needName {
+			id.name, _ = sample.proc.Name()
+		}
+		if c.needCmdline {
+			id.cmdline, _ = sample.proc.Cmdline()
+		}
+	}
+	if !c.needUser {
+		return id
+	}
+
+	var uid int32
+	var hasUID bool
+	if sample.hasStat {
+		uid, hasUID = readProcUID(c.procRoot, sample.key.pid)
+	} else if uids, err := sample.proc.Uids(); err == nil && len(uids) > 0 {
+		uid, hasUID = uids[0], true
+	}
+	if prev == nil || prev.hasUID != hasUID || prev.uid != uid {
+		id.user = ""
+		if hasUID {
+			id.user = cachedUsername(&c.usernames, uid)
+		}
+	}
+	id.uid, id.hasUID = uid, hasUID
+	return id
+}
+
+// evaluate 与上一周期的实例集合比较；数量异常优先于重启
+// 首次检测只建立基线，不报告重启
+func (r *processRule) evaluate(current map[processKey]bool) (string, string) {
+	restarted := 0
+	if r.started {
+		for key := range r.prev {
+			if !current[key] {
+				restarted++
+			}
+		}
+		// 只有实例消失而没有新实例时属于数量变化，不算重启
+		if restarted > 0 && len(current) < len(r.prev) {
+			restarted -= len(r.prev) - len(current)
+		}
+	}
+	r.prev = current
+	r.started = true
+
+	count := len(current)
+	expected := fmt.Sprintf("%d", r.min)
+	if r.max != r.min {
+		if r.max > 0 {
+			expected = fmt.Sprintf("%d-%d", r.min, r.max)
+		} else {
+			expected = fmt.Sprintf(">=%d", r.min)
+		}
+	}
+
+	switch {
+	case count == 0:
+		return processRuleMissing, fmt.Sprintf("no matching process, expected %s", expected)
+	case count < r.min:
+		return processRuleTooFew, fmt.Sprintf("%d instances, expected %s", count, expected)
+	case r.max > 0 && count > r.max:
+		return processRuleTooMany, fmt.Sprintf("%d instances, expected %s", count, expected)
+	case restarted > 0:
+		return processRuleRestarted, fmt.Sprintf("%d instances restarted since last check", restarted)
+	default:
+		return processRuleRunning, fmt.Sprintf("%d instances, expected %s", count, expected)
+	}
+}
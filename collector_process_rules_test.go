@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+func processKeys(keys ...processKey) map[processKey]bool {
+	result := make(map[processKey]bool)
+	for _, key := range keys {
+		result[key] = true
+	}
+	return result
+}
+
+func TestProcessRuleEvaluate(t *testing.T) {
+	collector := NewProcessRuleCollector([]ProcessRuleConfig{{Name: "php-fpm", Match: ProcessMatcherConfig{Name: "php-fpm"}, Count: 2}}, nil)
+	rule := collector.rules[0]
+	a, b, c := processKey{pid: 10, createTime: 1}, processKey{pid: 11, createTime: 1}, processKey{pid: 12, createTime: 2}
+
+	steps := []struct {
+		current map[processKey]bool
+		status  string
+	}{
+		{processKeys(a, b), processRuleRunning},
+		{processKeys(a, c), processRuleRestarted},
+		{processKeys(a), processRuleTooFew},
+		{processKeys(a, b, c), processRuleTooMany},
+		{processKeys(), processRuleMissing},
+		// PID 相同但创建时间变化也视为重启
+		{processKeys(a, b), processRuleRunning},
+		{processKeys(a, processKey{pid: 11, createTime: 9}), processRuleRestarted},
+	}
+	for i, step := range steps {
+		if status, description := rule.evaluate(step.current); status != step.status {
+			t.Fatalf("step %d: status = %q (%s), want %q", i, status, description, step.status)
+		}
+	}
+}
+
+func TestProcessRuleCollectorReportsServiceStatus(t *testing.T) {
+	self, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("new process: %v", err)
+	}
+	name, _ := self.Name()
+
+	collector := NewProcessRuleCollector([]ProcessRuleConfig{
+		{Name: "self", Match: ProcessMatcherConfig{Name: name}},
+		{Name: "billing", Match: ProcessMatcherConfig{Cmdline: "-Dapp=billing"}, Min: 2, Max: 4},
+		{Name: "invalid"},
+	}, nil)
+	if len(collector.rules) != 2 {
+		t.Fatalf("expected rule without matcher to be dropped, got %d rules", len(collector.rules))
+	}
+
+	createTime, err := self.CreateTime()
+	if err != nil {
+		t.Fatalf("create time: %v", err)
+	}
+	samples := []processSample{{key: processKey{pid: self.Pid, createTime: createTime}, proc: self}}
+	metrics := collector.collectFrom(samples, time.Now())
+	if len(metrics.Services) != 2 {
+		t.Fatalf("expected 2 rule statuses, got %#v", metrics.Services)
+	}
+	found := metrics.Services[0]
+	if found.Status != processRuleRunning || found.Source != "process_rule" || found.Instances != 1 || len(found.PIDs) != 1 || found.PIDs[0] != self.Pid {
+		t.Fatalf("unexpected status for running rule: %#v", found)
+	}
+	if missing := metrics.Services[1]; missing.Status != "stopped" || missing.Description != "no matching process, expected 2-4" {
+		t.Fatalf("unexpected status for missing rule: %#v", missing)
+	}
+}
+
+type fakeProcessSampleSource struct {
+	samples []processSample
+}
+
+func (s *fakeProcessSampleSource) takeProcessSamples() []processSample {
+	samples := s.samples
+	s.samples = nil
+	return samples
+}
+
+func TestProcessRuleCollectorReusesSamplesAndIdentity(t *testing.T) {
+	self, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("new process: %v", err)
+	}
+	key := processKey{pid: self.Pid, createTime: 1000}
+	source := &fakeProcessSampleSource{}
+	collector := NewProcessRuleCollector([]ProcessRuleConfig{
+		{Name: "billing", Match: ProcessMatcherConfig{Cmdline: "-Dapp=billing"}},
+	}, source)
+
+	// 已跟踪的进程沿用上一周期读取的命令行，创建时间取自采样的 key
+	collector.identities = map[processKey]*processIdentity{key: {cmdline: "java -Dapp=billing"}}
+	source.samples = []processSample{{key: key, proc: self}}
+	metrics, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	billing := metrics.(*ServiceMetrics).Services[0]
+	if billing.Status != processRuleRunning || billing.Instances != 1 || billing.Uptime <= 0 {
+		t.Fatalf("expected rule to match the cached cmdline of the sampled process, got %#v", billing)
+	}
+
+	// 进程退出后不再保留其字段
+	collector.collectFrom([]processSample{}, time.Now())
+	if len(collector.identities) != 0 {
+		t.Fatalf("expected identities of exited processes to be dropped, got %d", len(collector.identities))
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProcessStateCollector 全机进程状态汇总：按 R/S/D/Z/T 等状态计数，列出僵尸进程及其父进程、
+// 持续处于 D 状态超过阈值的进程，以及 RSS 在连续多个周期内持续增长的疑似内存泄漏进程
+// 优先复用进程收集器本周期读取的 /proc/<pid>/stat，进程收集器未运行时自行读取
+type ProcessStateCollector struct {
+	procRoot       string
+	source         procStatSource
+	dstateAfter    time.Duration
+	leakSamples    int
+	leakMinGrowth  uint64
+	pageSize       uint64
+	dstateSince    map[processKey]time.Time
+	rssHistory     map[processKey][]uint64
+	maxListEntries int
+}
+
+// procStat /proc/<pid>/stat 中用到的字段
+type procStat struct {
+	pid       int32
+	name      string
+	state     string
+	ppid      int32
+	startTime int64 // 开机后的时钟节拍数，与 PID 一起标识进程
+	rssPages  uint64
+	utime     uint64 // 用户态 CPU 时钟节拍数
+	stime     uint64 // 内核态 CPU 时钟节拍数
+}
+
+// procStatSource 提供其他收集器本周期已读取的 stat，每次采集的结果只能取走一次
+type procStatSource interface {
+	takeProcStats() []procStat
+}
+
+// procClockTicks /proc/<pid>/stat 中时间字段的单位（USER_HZ），Linux 上固定为100
+const procClockTicks = 100
+
+func NewProcessStateCollector(procRoot string, config ProcessConfig, source procStatSource) *ProcessStateCollector {
+	if procRoot == "" {
+		procRoot = "/proc"
+	}
+	c := &ProcessStateCollector{
+		procRoot:       procRoot,
+		source:         source,
+		dstateAfter:    time.Duration(config.DStateThreshold) * time.Second,
+		leakSamples:    config.LeakSamples,
+		leakMinGrowth:  uint64(config.LeakMinGrowthMB) << 20,
+		pageSize:       uint64(os.Getpagesize()),
+		dstateSince:    make(map[processKey]time.Time),
+		rssHistory:     make(map[processKey][]uint64),
+		maxListEntries: 50,
+	}
+	if c.dstateAfter <= 0 {
+		c.dstateAfter = time.Minute
+	}
+	// 少于3个样本无法区分持续增长和偶然波动
+	switch {
+	case c.leakSamples <= 0:
+		c.leakSamples = 6
+	case c.leakSamples < 3:
+		c.leakSamples = 3
+	}
+	return c
+}
+
+func (c *ProcessStateCollector) Name() string {
+	return "process_state"
+}
+
+func (c *ProcessStateCollector) Collect() (interface{}, error) {
+	return c.collectAt(time.Now())
+}
+
+func (c *ProcessStateCollector) collectAt(now time.Time) (*ProcessStateMetrics, error) {
+	var stats []procStat
+	if c.source != nil {
+		stats = c.source.takeProcStats()
+	}
+	if stats == nil {
+		var err error
+		if stats, err = readAllProcStats(c.procRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	metrics := &ProcessStateMetrics{
+		Total:        len(stats),
+		States:       make(map[string]int),
+		Zombies:      make([]ZombieProcess, 0),
+		StuckDState:  make([]StuckProcess, 0),
+		LeakSuspects: make([]LeakSuspect, 0),
+	}
+	names := make(map[int32]string, len(stats))
+	for _, stat := range stats {
+		names[stat.pid] = stat.name
+	}
+
+	dstateSince := make(map[processKey]time.Time)
+	rssHistory := make(map[processKey][]uint64, len(stats))
+	for _, stat := range stats {
+		metrics.States[stat.state]++
+		key := processKey{pid: stat.pid, createTime: stat.startTime}
+
+		switch stat.state {
+		case "Z":
+			metrics.Zombies = append(metrics.Zombies, ZombieProcess{
+				PID:        stat.pid,
+				Name:       stat.name,
+				PPID:       stat.ppid,
+				ParentName: names[stat.ppid],
+			})
+		case "D":
+			since, ok := c.dstateSince[key]
+			if !ok {
+				since = now
+			}
+			dstateSince[key] = since
+			if stuck := now.Sub(since); stuck >= c.dstateAfter {
+				metrics.StuckDState = append(metrics.StuckDState, StuckProcess{
+					PID:     stat.pid,
+					Name:    stat.name,
+					Seconds: int64(stuck.Seconds()),
+				})
+			}
+		}
+
+		// 内核线程和僵尸进程没有 RSS，不参与泄漏检测
+		if stat.rssPages == 0 {
+			continue
+		}
+		history := append(c.rssHistory[key], stat.rssPages*c.pageSize)
+		if len(history) > c.leakSamples {
+			history = history[len(history)-c.leakSamples:]
+		}
+		rssHistory[key] = history
+		if growth, ok := steadyGrowth(history, c.leakSamples, c.leakMinGrowth); ok {
+			metrics.LeakSuspects = append(metrics.LeakSuspects, LeakSuspect{
+				PID:         stat.pid,
+				Name:        stat.name,
+				RSSBytes:    history[len(history)-1],
+				GrowthBytes: growth,
+				Samples:     len(history),
+			})
+		}
+	}
+	c.dstateSince = dstateSince
+	c.rssHistory = rssHistory
+
+	sort.Slice(metrics.Zombies, func(i, j int) bool { return metrics.Zombies[i].PID < metrics.Zombies[j].PID })
+	sort.Slice(metrics.StuckDState, func(i, j int) bool { return metrics.StuckDState[i].Seconds > metrics.StuckDState[j].Seconds })
+	sort.Slice(metrics.LeakSuspects, func(i, j int) bool { return metrics.LeakSuspects[i].GrowthBytes > metrics.LeakSuspects[j].GrowthBytes })
+	metrics.Zombies = truncateList(metrics.Zombies, c.maxListEntries)
+	metrics.StuckDState = truncateList(metrics.StuckDState, c.maxListEntries)
+	metrics.LeakSuspects = truncateList(metrics.LeakSuspects, c.maxListEntries)
+	return metrics, nil
+}
+
+// steadyGrowth 样本数达到窗口大小、从不下降且总增长达到阈值时判定为持续增长
+func steadyGrowth(history []uint64, window int, minGrowth uint64) (uint64, bool) {
+	if len(history) < window {
+		return 0, false
+	}
+	for i := 1; i < len(history); i++ {
+		if history[i] < history[i-1] {
+			return 0, false
+		}
+	}
+	growth := history[len(history)-1] - history[0]
+	if growth == 0 || growth < minGrowth {
+		return 0, false
+	}
+	return growth, true
+}
+
+func truncateList[T any](list []T, limit int) []T {
+	if len(list) > limit {
+		return list[:limit]
+	}
+	return list
+}
+
+func readAllProcStats(procRoot string) ([]procStat, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]procStat, 0, len(entries))
+	for _, entry := range entries {
+		if _, err := strconv.ParseInt(entry.Name(), 10, 32); err != nil {
+			continue
+		}
+		if stat, ok := readProcStat(procRoot, entry.Name()); ok {
+			stats = append(stats, stat)
+		}
+	}
+	return stats, nil
+}
+
+// readProcStat 读取单个进程的 stat，进程已退出或非 Linux 系统时返回 false
+func readProcStat(procRoot, pid string) (procStat, bool) {
+	data, err := os.ReadFile(filepath.Join(procRoot, pid, "stat"))
+	if err != nil {
+		return procStat{}, false
+	}
+	return parseProcStat(string(data))
+}
+
+// parseProcStat 进程名位于括号内且可能包含空格和括号，以最后一个 ")" 为界
+func parseProcStat(data string) (procStat, bool) {
+	start := strings.IndexByte(data, '(')
+	end := strings.LastIndexByte(data, ')')
+	if start < 0 || end < start {
+		return procStat{}, false
+	}
+	pid, err := strconv.ParseInt(strings.TrimSpace(data[:start]), 10, 32)
+	if err != nil {
+		return procStat{}, false
+	}
+	// 括号后依次为 state(3) ppid(4) ... utime(14) stime(15) ... starttime(22) vsize(23) rss(24)
+	fields := strings.Fields(data[end+1:])
+	if len(fields) < 22 {
+		return procStat{}, false
+	}
+	ppid, _ := strconv.ParseInt(fields[1], 10, 32)
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	startTime, _ := strconv.ParseInt(fields[19], 10, 64)
+	rss, _ := strconv.ParseInt(fields[21], 10, 64)
+	if rss < 0 {
+		rss = 0
+	}
+	return procStat{
+		pid:       int32(pid),
+		name:      data[start+1 : end],
+		state:     fields[0],
+		ppid:      int32(ppid),
+		startTime: startTime,
+		rssPages:  uint64(rss),
+		utime:     utime,
+		stime:     stime,
+	}, true
+}
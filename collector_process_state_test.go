@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeProcStat(t *testing.T, root string, pid int, comm, state string, ppid int, startTime int64, rssPages uint64) {
+	t.Helper()
+	dir := filepath.Join(root, fmt.Sprint(pid))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	content := fmt.Sprintf("%d (%s) %s %d 1 1 0 -1 4194560 100 0 0 0 10 5 0 0 20 0 1 0 %d 1000000 %d 18446744073709551615\n",
+		pid, comm, state, ppid, startTime, rssPages)
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(content), 0644); err != nil {
+		t.Fatalf("write stat: %v", err)
+	}
+}
+
+func TestParseProcStatHandlesParenthesesInName(t *testing.T) {
+	stat, ok := parseProcStat("42 (tmux: server (1)) S 1 42 42 0 -1 4194560 100 0 0 0 10 5 0 0 20 0 1 0 12345 1000000 256 18446744073709551615\n")
+	if !ok {
+		t.Fatal("expected stat to parse")
+	}
+	if stat.pid != 42 || stat.name != "tmux: server (1)" || stat.state != "S" || stat.ppid != 1 || stat.startTime != 12345 || stat.rssPages != 256 {
+		t.Fatalf("unexpected stat: %#v", stat)
+	}
+}
+
+func TestProcessStateCollectorSummarizesStates(t *testing.T) {
+	root := t.TempDir()
+	writeProcStat(t, root, 1, "systemd", "S", 0, 1, 1000)
+	writeProcStat(t, root, 100, "worker", "R", 1, 50, 1000)
+	writeProcStat(t, root, 200, "supervisor", "S", 1, 60, 1000)
+	writeProcStat(t, root, 201, "child", "Z", 200, 70, 0)
+	writeProcStat(t, root, 300, "nfs-client", "D", 1, 80, 1000)
+	writeProcStat(t, root, 2, "kthreadd", "I", 0, 2, 0)
+
+	collector := NewProcessStateCollector(root, ProcessConfig{DStateThreshold: 60, LeakSamples: 3}, nil)
+	start := time.Unix(1700000000, 0)
+	metrics, err := collector.collectAt(start)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if metrics.Total != 6 || metrics.States["S"] != 2 || metrics.States["Z"] != 1 || metrics.States["D"] != 1 || metrics.States["I"] != 1 {
+		t.Fatalf("unexpected state counts: total=%d states=%v", metrics.Total, metrics.States)
+	}
+	if len(metrics.Zombies) != 1 || metrics.Zombies[0] != (ZombieProcess{PID: 201, Name: "child", PPID: 200, ParentName: "supervisor"}) {
+		t.Fatalf("unexpected zombies: %#v", metrics.Zombies)
+	}
+	if len(metrics.StuckDState) != 0 {
+		t.Fatalf("D state should not be reported before threshold: %#v", metrics.StuckDState)
+	}
+
+	metrics, _ = collector.collectAt(start.Add(90 * time.Second))
+	if len(metrics.StuckDState) != 1 || metrics.StuckDState[0].PID != 300 || metrics.StuckDState[0].Seconds != 90 {
+		t.Fatalf("unexpected stuck processes: %#v", metrics.StuckDState)
+	}
+
+	// 进程恢复后再次进入 D 状态重新计时
+	writeProcStat(t, root, 300, "nfs-client", "S", 1, 80, 1000)
+	collector.collectAt(start.Add(120 * time.Second))
+	writeProcStat(t, root, 300, "nfs-client", "D", 1, 80, 1000)
+	metrics, _ = collector.collectAt(start.Add(150 * time.Second))
+	if len(metrics.StuckDState) != 0 {
+		t.Fatalf("D state timer should restart: %#v", metrics.StuckDState)
+	}
+}
+
+func TestProcessStateCollectorDetectsLeakSuspects(t *testing.T) {
+	root := t.TempDir()
+	collector := NewProcessStateCollector(root, ProcessConfig{LeakSamples: 2, LeakMinGrowthMB: 1}, nil)
+	if collector.leakSamples != 3 {
+		t.Fatalf("leak_samples below 3 should be clamped to 3, got %d", collector.leakSamples)
+	}
+	pages := uint64(1<<20) / collector.pageSize
+	start := time.Unix(1700000000, 0)
+
+	rss := []uint64{10, 11, 13}
+	for i, mb := range rss {
+		writeProcStat(t, root, 100, "leaky", "S", 1, 50, mb*pages)
+		writeProcStat(t, root, 200, "steady", "S", 1, 60, 10*pages)
+		writeProcStat(t, root, 300, "sawtooth", "S", 1, 70, []uint64{20, 10, 30}[i]*pages)
+		metrics, err := collector.collectAt(start.Add(time.Duration(i) * time.Minute))
+		if err != nil {
+			t.Fatalf("collect: %v", err)
+		}
+		if i < len(rss)-1 && len(metrics.LeakSuspects) != 0 {
+			t.Fatalf("tick %d: leak reported before window filled: %#v", i, metrics.LeakSuspects)
+		}
+		if i == len(rss)-1 {
+			if len(metrics.LeakSuspects) != 1 {
+				t.Fatalf("expected one leak suspect, got %#v", metrics.LeakSuspects)
+			}
+			suspect := metrics.LeakSuspects[0]
+			if suspect.PID != 100 || suspect.GrowthBytes != 3*pages*collector.pageSize || suspect.RSSBytes != 13*pages*collector.pageSize || suspect.Samples != 3 {
+				t.Fatalf("unexpected leak suspect: %#v", suspect)
+			}
+		}
+	}
+
+	// PID 被复用为新进程时历史清零
+	writeProcStat(t, root, 100, "leaky", "S", 1, 999, 14*pages)
+	metrics, _ := collector.collectAt(start.Add(3 * time.Minute))
+	for _, suspect := range metrics.LeakSuspects {
+		if suspect.PID == 100 {
+			t.Fatalf("reused PID should start a new history: %#v", suspect)
+		}
+	}
+}
+
+type fakeProcStatSource struct {
+	stats []procStat
+}
+
+func (s *fakeProcStatSource) takeProcStats() []procStat {
+	stats := s.stats
+	s.stats = nil
+	return stats
+}
+
+func TestProcessStateCollectorReusesSharedStats(t *testing.T) {
+	root := t.TempDir()
+	writeProcStat(t, root, 1, "systemd", "S", 0, 1, 1000)
+	source := &fakeProcStatSource{stats: []procStat{
+		{pid: 1, name: "systemd", state: "S", startTime: 1, rssPages: 1000},
+		{pid: 10, name: "defunct", state: "Z", ppid: 1, startTime: 5},
+	}}
+	collector := NewProcessStateCollector(root, ProcessConfig{}, source)
+
+	metrics, err := collector.collectAt(time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if metrics.Total != 2 || len(metrics.Zombies) != 1 || metrics.Zombies[0].ParentName != "systemd" {
+		t.Fatalf("expected stats from the shared source, got %#v", metrics)
+	}
+
+	// 共享的结果已被取走时自行读取 /proc
+	metrics, _ = collector.collectAt(time.Unix(1700000060, 0))
+	if metrics.Total != 1 || len(metrics.Zombies) != 0 {
+		t.Fatalf("expected fallback to procRoot, got %#v", metrics)
+	}
+}
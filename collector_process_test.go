@@ -1,6 +1,14 @@
 package main
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
 
 func TestNormalizeProcessStatusUsesTopLetters(t *testing.T) {
 	tests := map[string]string{
@@ -19,3 +27,204 @@ func TestNormalizeProcessStatusUsesTopLetters(t *testing.T) {
 		}
 	}
 }
+
+func TestProcessCollectorTracksHandlesAcrossTicks(t *testing.T) {
+	collector := NewProcessCollector(ProcessConfig{MaxCount: 10}, nil, nil)
+	pid := int32(os.Getpid())
+	start := time.Now()
+
+	first := collector.sample([]int32{pid}, start)
+	if len(first) != 1 || first[0].cpuPercent != 0 {
+		t.Fatalf("expected one sample without cpu on first tick, got %#v", first)
+	}
+
+	// 消耗一些CPU时间
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for time.Now().Before(deadline) {
+	}
+
+	second := collector.sample([]int32{pid, 1 << 30}, start.Add(time.Second))
+	if len(second) != 1 {
+		t.Fatalf("expected nonexistent pid to be skipped, got %d samples", len(second))
+	}
+	if second[0].proc != first[0].proc {
+		t.Fatal("expected process handle to be reused between ticks")
+	}
+	if second[0].cpuPercent <= 0 {
+		t.Fatalf("expected positive cpu percent from tick delta, got %.2f", second[0].cpuPercent)
+	}
+	if len(collector.tracked) != 1 {
+		t.Fatalf("expected only live processes to be tracked, got %d", len(collector.tracked))
+	}
+}
+
+func TestProcessCollectorDropsStaleIOSnapshot(t *testing.T) {
+	collector := NewProcessCollector(ProcessConfig{MaxCount: 10}, nil, nil)
+	pid := int32(os.Getpid())
+	start := time.Now()
+
+	first := collector.sample([]int32{pid}, start)
+	snapshot := processIOSnapshot{readBytes: 1, writeBytes: 1, time: start}
+	collector.tracked[first[0].key].io = snapshot
+	if second := collector.sample([]int32{pid}, start.Add(time.Second)); second[0].io != snapshot {
+		t.Fatalf("snapshot from the previous tick should be kept, got %#v", second[0].io)
+	}
+
+	// 第二个周期未上报，第三个周期不能用两个周期前的快照计算速率
+	third := collector.sample([]int32{pid}, start.Add(2*time.Second))
+	if !third[0].io.time.IsZero() {
+		t.Fatalf("stale snapshot should be dropped, got %#v", third[0].io)
+	}
+}
+
+func TestProcessCPUPercent(t *testing.T) {
+	if got := processCPUPercent(10, 25, 10); got != 150 {
+		t.Fatalf("expected 150%% for 1.5 cores, got %.2f", got)
+	}
+	if got := processCPUPercent(10, 5, 10); got != 0 {
+		t.Fatalf("expected 0 when cpu time goes backwards, got %.2f", got)
+	}
+}
+
+func TestProcessCollectorSortKeysAndWatchList(t *testing.T) {
+	collector := NewProcessCollector(ProcessConfig{
+		MaxCount: 2,
+		SortBy:   "threads",
+		Watch:    []ProcessMatcherConfig{{Name: "critical-daemon"}},
+	}, nil, nil)
+	if collector.sortBy != "threads" {
+		t.Fatalf("expected threads sort key, got %q", collector.sortBy)
+	}
+
+	samples := []processSample{
+		{key: processKey{pid: 1}, rank: 5},
+		{key: processKey{pid: 2}, rank: 50},
+		{key: processKey{pid: 3}, rank: 0, watched: true},
+		{key: processKey{pid: 4}, rank: 20},
+		{key: processKey{pid: 5}, rank: 1},
+	}
+	selected := collector.selectSamples(samples)
+
+	var pids []int32
+	for _, sample := range selected {
+		pids = append(pids, sample.key.pid)
+	}
+	want := []int32{2, 4, 3}
+	if len(pids) != len(want) {
+		t.Fatalf("selected pids = %v, want %v", pids, want)
+	}
+	for i := range want {
+		if pids[i] != want[i] {
+			t.Fatalf("selected pids = %v, want %v", pids, want)
+		}
+	}
+
+	if fallback := NewProcessCollector(ProcessConfig{SortBy: "bogus"}, nil, nil); fallback.sortBy != "cpu" || fallback.maxProcesses != 50 {
+		t.Fatalf("expected defaults for invalid config, got sort=%q max=%d", fallback.sortBy, fallback.maxProcesses)
+	}
+}
+
+func TestProcessMatcherMatchesCurrentProcess(t *testing.T) {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("new process: %v", err)
+	}
+	name, _ := proc.Name()
+	username, _ := proc.Username()
+
+	matchers := compileProcessMatchers([]ProcessMatcherConfig{
+		{Name: name, Cmdline: `\.test`, User: username},
+		{Cmdline: "("},
+		{},
+	})
+	if len(matchers) != 1 {
+		t.Fatalf("expected invalid and empty matchers to be dropped, got %d", len(matchers))
+	}
+	if !matchers[0].match(proc) {
+		t.Fatal("expected matcher to match the test process")
+	}
+
+	other, _ := newProcessMatcher(ProcessMatcherConfig{Name: name, User: "no-such-user"})
+	if other.match(proc) {
+		t.Fatal("expected all configured conditions to be required")
+	}
+}
+
+const processTestContainerID = "fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+
+func TestReadProcessCgroupPrefersUnifiedHierarchy(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"1": "0::/system.slice/nginx.service\n",
+		"2": "12:memory:/docker/" + processTestContainerID + "\n1:name=systemd:/system.slice/docker-" + processTestContainerID + ".scope\n0::/\n",
+		"3": "4:memory:/kubepods/burstable/pod1/" + processTestContainerID + "\n3:cpu,cpuacct:/kubepods/burstable/pod1/" + processTestContainerID + "\n",
+	}
+	for pid, content := range files {
+		if err := os.MkdirAll(filepath.Join(root, pid), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, pid, "cgroup"), []byte(content), 0644); err != nil {
+			t.Fatalf("write cgroup: %v", err)
+		}
+	}
+
+	tests := []struct {
+		pid         int32
+		path        string
+		containerID string
+		unit        string
+	}{
+		{1, "/system.slice/nginx.service", "", "nginx.service"},
+		{2, "/system.slice/docker-" + processTestContainerID + ".scope", processTestContainerID, ""},
+		{3, "/kubepods/burstable/pod1/" + processTestContainerID, processTestContainerID, ""},
+	}
+	for _, tt := range tests {
+		path := readProcessCgroup(root, tt.pid)
+		containerID, unit := processCgroupWorkload(path)
+		if path != tt.path || containerID != tt.containerID || unit != tt.unit {
+			t.Fatalf("pid %d: got (%q, %q, %q), want (%q, %q, %q)", tt.pid, path, containerID, unit, tt.path, tt.containerID, tt.unit)
+		}
+	}
+
+	// 容器内进程位于 scope 的子 cgroup
+	if containerID, _ := processCgroupWorkload("/system.slice/docker-" + processTestContainerID + ".scope/init"); containerID != processTestContainerID {
+		t.Fatalf("expected nested cgroup to resolve container, got %q", containerID)
+	}
+}
+
+func TestGetProcessInfoIncludesExtendedFields(t *testing.T) {
+	collector := NewProcessCollector(ProcessConfig{}, nil, nil)
+	pid := int32(os.Getpid())
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		t.Fatalf("new process: %v", err)
+	}
+
+	// cgroup 按配置的 procRoot 读取，状态沿用采样时的 stat
+	collector.procRoot = t.TempDir()
+	pidDir := filepath.Join(collector.procRoot, strconv.Itoa(int(pid)))
+	if err := os.MkdirAll(pidDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pidDir, "cgroup"), []byte("0::/system.slice/agent.service\n"), 0644); err != nil {
+		t.Fatalf("write cgroup: %v", err)
+	}
+	sample := processSample{proc: proc, stat: procStat{state: "D"}, hasStat: true}
+
+	info, err := collector.getProcessInfo(sample, 0)
+	if err != nil {
+		t.Fatalf("getProcessInfo: %v", err)
+	}
+	if info.PPID != int32(os.Getppid()) {
+		t.Fatalf("expected ppid %d, got %d", os.Getppid(), info.PPID)
+	}
+	if info.NumThreads <= 0 || info.NumFDs <= 0 {
+		t.Fatalf("expected thread and fd counts, got threads=%d fds=%d", info.NumThreads, info.NumFDs)
+	}
+	if info.CgroupPath != "/system.slice/agent.service" || info.Unit != "agent.service" {
+		t.Fatalf("expected cgroup from procRoot, got path=%q unit=%q", info.CgroupPath, info.Unit)
+	}
+	if info.Status != "D" {
+		t.Fatalf("expected status from the sampled stat, got %q", info.Status)
+	}
+}
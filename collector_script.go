@@ -16,7 +16,8 @@ import (
 
 // ScriptExecutor 脚本执行器
 type ScriptExecutor struct {
-	scripts []ScriptConfig // 要执行的脚本配置
+	scripts  []ScriptConfig // 要执行的脚本配置
+	redactor *Redactor
 }
 
 // ScriptConfig 脚本配置
@@ -31,9 +32,10 @@ type ScriptConfig struct {
 }
 
 // NewScriptExecutor 创建脚本执行器
-func NewScriptExecutor(scripts []ScriptConfig) *ScriptExecutor {
+func NewScriptExecutor(scripts []ScriptConfig, redactor *Redactor) *ScriptExecutor {
 	return &ScriptExecutor{
-		scripts: scripts,
+		scripts:  scripts,
+		redactor: redactor,
 	}
 }
 
@@ -124,6 +126,8 @@ func (e *ScriptExecutor) executeScript(config ScriptConfig) ScriptResult {
 		result.ExitCode = 0
 	}
 
+	result.Output = e.redactor.Redact(result.Output)
+	result.Error = e.redactor.Redact(result.Error)
 	return result
 }
 
@@ -1,10 +1,10 @@
 package main
 
 import (
-	"fmt"
 	"net"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -138,7 +138,7 @@ func serviceInfoFromPortCheck(config ServicePortConfig, portAccessible bool) *Se
 }
 
 func (s *ServiceCollector) probePort(host string, port int) bool {
-	address := fmt.Sprintf("%s:%d", host, port)
+	address := net.JoinHostPort(host, strconv.Itoa(port))
 	conn, err := net.DialTimeout("tcp", address, 3*time.Second)
 	if err != nil {
 		return false
@@ -155,6 +155,10 @@ type ServiceInfo struct {
 	Uptime         int64  `json:"uptime_seconds"`
 	Port           int    `json:"port,omitempty"`
 	PortAccessible bool   `json:"port_accessible,omitempty"`
+	// 进程规则检测结果，Source 为 process_rule
+	Source    string  `json:"source,omitempty"`
+	Instances int     `json:"instances,omitempty"`
+	PIDs      []int32 `json:"pids,omitempty"`
 }
 
 type ServiceMetrics struct {
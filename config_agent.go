@@ -18,12 +18,44 @@ type AgentConfig struct {
 	ManualIP        string              `yaml:"manual_ip"`
 	Debug           bool                `yaml:"debug"`
 	LogPaths        []string            `yaml:"log_paths"`     // 日志文件路径列表
+	Log             LogConfig           `yaml:"log"`           // 日志跟踪读取配置
 	Scripts         []ScriptConfig      `yaml:"scripts"`       // 脚本配置列表
 	Services        []string            `yaml:"services"`      // 要检测的服务列表（兼容旧格式）
 	ServicePorts    []ServicePortConfig `yaml:"service_ports"` // 服务端口配置（新格式，支持端口检查）
 	GRPC            GRPCConfig          `yaml:"grpc"`          // gRPC连接与请求超时配置
 	Fallback        FallbackConfig      `yaml:"fallback"`      // gRPC失败后的HTTP兜底和本地缓存配置
 	GPU             GPUConfig           `yaml:"gpu"`
+	Cgroup          CgroupConfig        `yaml:"cgroup"`        // cgroup v2 服务/容器资源采集配置
+	Container       ContainerConfig     `yaml:"container"`     // 容器运行时配置
+	Disk            DiskConfig          `yaml:"disk"`          // 磁盘分区过滤配置
+	Network         NetworkConfig       `yaml:"network"`       // 网卡过滤配置
+	Process         ProcessConfig       `yaml:"process"`       // 进程采集配置
+	ProcessRules    []ProcessRuleConfig `yaml:"process_rules"` // 进程存活规则，结果按服务状态上报
+	Redaction       RedactionConfig     `yaml:"redaction"`     // 命令行、日志和脚本输出脱敏
+}
+
+type LogConfig struct {
+	PositionsFile    string `yaml:"positions_file"`      // 各文件读取位置的持久化文件
+	MaxLinesPerCycle int    `yaml:"max_lines_per_cycle"` // 每个文件每周期最多读取的行数
+	MaxBytesPerCycle int64  `yaml:"max_bytes_per_cycle"` // 每个文件每周期最多读取的字节数
+	StartAt          string `yaml:"start_at"`            // 没有记录位置的文件首次从 end 或 beginning 开始读
+
+	ExcludePaths   []string `yaml:"exclude_paths"`   // 排除的文件，支持 glob
+	MaxFiles       int      `yaml:"max_files"`       // 同时跟踪的最大文件数，超过时保留最近修改的
+	RescanInterval int      `yaml:"rescan_interval"` // 重新展开目录和通配模式的间隔（秒）
+
+	Parsers []LogParserConfig `yaml:"parsers"` // 按文件配置的日志格式，未匹配的文件按纯文本处理
+}
+
+type LogParserConfig struct {
+	Paths        []string `yaml:"paths"`         // 适用的日志文件，支持 glob，先配置的优先
+	Format       string   `yaml:"format"`        // plain、json、regex、nginx、apache、syslog、logfmt
+	Pattern      string   `yaml:"pattern"`       // regex 格式的正则，命名分组成为字段
+	MessageField string   `yaml:"message_field"` // 默认 message、msg
+	LevelField   string   `yaml:"level_field"`   // 默认 level、lvl、severity
+	TimeField    string   `yaml:"time_field"`    // 默认 time、timestamp、ts、@timestamp
+	TimeLayout   string   `yaml:"time_layout"`   // Go 时间格式、unix 或 unix_ms，默认自动识别常见格式
+	Timezone     string   `yaml:"timezone"`      // 时间不带时区时使用，如 Asia/Shanghai，默认本地时区
 }
 
 type GRPCConfig struct {
@@ -44,6 +76,75 @@ type GPUConfig struct {
 	FieldMappings map[string]string `yaml:"field_mappings"`
 }
 
+type CgroupConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Root    string `yaml:"root"` // cgroup v2 挂载点，默认 /sys/fs/cgroup
+}
+
+type ContainerConfig struct {
+	Runtime string `yaml:"runtime"` // auto、docker、podman、cri
+	Socket  string `yaml:"socket"`  // 运行时 socket 路径（可选，auto 时按常见路径探测）
+	Timeout int    `yaml:"timeout"` // CRI 请求超时时间（秒）
+}
+
+// DiskConfig 磁盘分区过滤，挂载点和设备支持 glob（* 不跨目录，** 跨多级目录）
+type DiskConfig struct {
+	IncludeMountpoints []string `yaml:"include_mountpoints"`
+	ExcludeMountpoints []string `yaml:"exclude_mountpoints"`
+	IncludeDevices     []string `yaml:"include_devices"`
+	ExcludeDevices     []string `yaml:"exclude_devices"`
+	IncludeFstypes     []string `yaml:"include_fstypes"`
+	ExcludeFstypes     []string `yaml:"exclude_fstypes"`
+	DedupeDevices      bool     `yaml:"dedupe_devices"` // 同一设备多处挂载只上报一次
+
+	ForecastEnabled   bool   `yaml:"forecast_enabled"`    // 是否预测磁盘写满时间
+	ForecastWindow    int    `yaml:"forecast_window"`     // 趋势拟合使用的历史时长（秒）
+	ForecastStateFile string `yaml:"forecast_state_file"` // 历史数据持久化文件
+}
+
+// NetworkConfig 网卡过滤，名称支持 glob，排除优先
+type NetworkConfig struct {
+	IncludeInterfaces []string `yaml:"include_interfaces"`
+	ExcludeInterfaces []string `yaml:"exclude_interfaces"`
+	SysfsRoot         string   `yaml:"sysfs_root"`         // 网卡属性目录，默认 /sys/class/net
+	SkipEphemeralUDP  bool     `yaml:"skip_ephemeral_udp"` // 监听端口清单忽略临时端口范围内的 UDP 套接字
+}
+
+type ProcessConfig struct {
+	MaxCount  int                    `yaml:"max_count"` // 按排序字段上报的进程数
+	SortBy    string                 `yaml:"sort_by"`   // cpu、memory、io、fds、threads
+	Watch     []ProcessMatcherConfig `yaml:"watch"`     // 关注进程，不论排名都会上报
+	Aggregate bool                   `yaml:"aggregate"` // 按进程名、用户、容器/unit 汇总所有进程
+
+	DStateThreshold int `yaml:"dstate_threshold"`   // 持续处于 D 状态多少秒后上报
+	LeakSamples     int `yaml:"leak_samples"`       // RSS 连续增长多少个采集周期判定为疑似泄漏
+	LeakMinGrowthMB int `yaml:"leak_min_growth_mb"` // 检测窗口内最少增长（MB）
+	FDTopCount      int `yaml:"fd_top_count"`       // 打开文件数占 RLIMIT_NOFILE 比例最高的进程上报个数
+}
+
+// ProcessMatcherConfig 进程匹配条件，配置的字段需全部满足；cmdline 为正则
+type ProcessMatcherConfig struct {
+	Name    string `yaml:"name"`
+	Cmdline string `yaml:"cmdline"`
+	User    string `yaml:"user"`
+}
+
+// ProcessRuleConfig 进程存活规则；count 要求精确实例数，否则按 min/max 检查，默认至少1个
+type ProcessRuleConfig struct {
+	Name  string               `yaml:"name"`
+	Match ProcessMatcherConfig `yaml:"match"`
+	Count int                  `yaml:"count"`
+	Min   int                  `yaml:"min"`
+	Max   int                  `yaml:"max"`
+}
+
+// RedactionConfig 脱敏配置；内置规则覆盖常见密码参数、URL 中的账号密码和敏感环境变量
+type RedactionConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Mask     string   `yaml:"mask"`     // 替换文本，默认 ***
+	Patterns []string `yaml:"patterns"` // 自定义正则，有捕获组时只替换第一个捕获组
+}
+
 type FallbackConfig struct {
 	HTTPEnabled   bool   `yaml:"http_enabled"`
 	HTTPBaseURL   string `yaml:"http_base_url"`
@@ -79,6 +180,17 @@ func LoadAgentConfigFromPath(configFile string) *AgentConfig {
 		CollectInterval: 10,
 		ManualIP:        "",
 		Debug:           false,
+		Log: LogConfig{
+			PositionsFile:    "./agent-state/log-positions.json",
+			MaxLinesPerCycle: 1000,
+			MaxBytesPerCycle: 1 << 20,
+			StartAt:          "end",
+			ExcludePaths: []string{
+				"**/*.gz", "**/*.bz2", "**/*.xz", "**/*.zst", "**/*.zip", "**/*.[0-9]",
+			},
+			MaxFiles:       100,
+			RescanInterval: 300,
+		},
 		GRPC: GRPCConfig{
 			ConnectTimeout:   5,
 			RegisterTimeout:  5,
@@ -97,6 +209,56 @@ func LoadAgentConfigFromPath(configFile string) *AgentConfig {
 			Provider: "auto",
 			Timeout:  5,
 		},
+		Cgroup: CgroupConfig{
+			Enabled: true,
+			Root:    "/sys/fs/cgroup",
+		},
+		Container: ContainerConfig{
+			Runtime: "auto",
+			Timeout: 5,
+		},
+		Disk: DiskConfig{
+			ExcludeMountpoints: []string{
+				"/var/lib/docker/**",
+				"/var/lib/containers/**",
+				"/var/lib/kubelet/**",
+				"/run/containerd/**",
+				"/snap/**",
+			},
+			ExcludeDevices: []string{"/dev/loop*"},
+			ExcludeFstypes: []string{
+				"overlay", "tmpfs", "devtmpfs", "squashfs", "aufs", "nsfs",
+				"proc", "sysfs", "cgroup", "cgroup2", "devpts", "mqueue",
+				"autofs", "tracefs", "debugfs", "securityfs", "pstore", "bpf",
+				"configfs", "fusectl", "hugetlbfs", "ramfs", "binfmt_misc",
+				"efivarfs", "rpc_pipefs", "fuse.lxcfs",
+			},
+			DedupeDevices:     true,
+			ForecastEnabled:   true,
+			ForecastWindow:    21600,
+			ForecastStateFile: "./agent-state/disk-forecast.json",
+		},
+		Network: NetworkConfig{
+			ExcludeInterfaces: []string{
+				"lo", "veth*", "docker0", "br-*", "cni*", "flannel*",
+				"cali*", "vxlan*", "tunl*", "virbr*", "kube-ipvs*",
+			},
+			SysfsRoot: "/sys/class/net",
+		},
+		Process: ProcessConfig{
+			MaxCount:  50,
+			SortBy:    "cpu",
+			Aggregate: true,
+
+			DStateThreshold: 60,
+			LeakSamples:     6,
+			LeakMinGrowthMB: 50,
+			FDTopCount:      10,
+		},
+		Redaction: RedactionConfig{
+			Enabled: true,
+			Mask:    "***",
+		},
 	}
 
 	if configFile == "" {
@@ -5,9 +5,11 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"runtime"
 	"time"
 )
 
@@ -17,6 +19,7 @@ type Agent struct {
 	collectors      []Collector
 	reporter        *Reporter
 	logCollector    *LogCollector // 独立的日志收集器
+	fdTable         *procFDTable  // 各收集器共用的 /proc/<pid>/fd 遍历结果，每个采集周期重置
 }
 
 func NewAgent(hostID string, interval time.Duration, reporter *Reporter, config *AgentConfig) *Agent {
@@ -24,21 +27,35 @@ func NewAgent(hostID string, interval time.Duration, reporter *Reporter, config
 	collectors := []Collector{
 		&CPUCollector{},
 		&MemoryCollector{},
-		&DiskCollector{},
-		&NetworkCollector{},
+		NewDiskCollector(config.Disk),
+		NewNetworkCollector(config.Network),
 	}
 	collectors = append(collectors, NewGPUCollector(config.GPU))
+	collectors = append(collectors, NewCgroupCollector(config.Cgroup))
+	collectors = append(collectors, NewPressureCollector(""))
+	fdTable := newProcFDTable("")
+	if runtime.GOOS == "linux" {
+		collectors = append(collectors, NewKernelCollector(""))
+		collectors = append(collectors, NewNetstackCollector(""))
+		collectors = append(collectors, NewListenerCollector("", config.Network.SkipEphemeralUDP, fdTable))
+		collectors = append(collectors, NewLimitsCollector("", config.Process.FDTopCount, fdTable))
+	}
 
 	// 进程监控收集器
-	processCollector := NewProcessCollector(50) // 最多收集50个进程
+	redactor := NewRedactor(config.Redaction)
+	processCollector := NewProcessCollector(config.Process, redactor, fdTable)
 	collectors = append(collectors, processCollector)
-	collectors = append(collectors, NewDockerCollector())
+	if runtime.GOOS == "linux" {
+		// 放在进程收集器之后，复用其本周期读取的 /proc/<pid>/stat
+		collectors = append(collectors, NewProcessStateCollector("", config.Process, processCollector))
+	}
+	collectors = append(collectors, NewDockerCollector(config.Container))
 
 	var logCollector *LogCollector
 	if config.LogCollectionEnabled() {
 		log.Printf("Loaded %d log paths from config", len(config.LogPaths))
-		logCollector = NewLogCollector(config.LogPaths, 100) // 每个文件最多100行
-		collectors = append(collectors, logCollector)
+		// 日志按偏移量跟踪读取，只由日志定时器采集，避免指标周期把新增内容读走
+		logCollector = NewLogCollector(config.LogPaths, config.Log, redactor)
 	} else {
 		log.Printf("No log paths configured, log collection disabled")
 	}
@@ -46,7 +63,7 @@ func NewAgent(hostID string, interval time.Duration, reporter *Reporter, config
 	// 脚本执行器（从配置读取脚本列表）
 	if len(config.Scripts) > 0 {
 		log.Printf("Loaded %d scripts from config", len(config.Scripts))
-		scriptExecutor := NewScriptExecutor(config.Scripts)
+		scriptExecutor := NewScriptExecutor(config.Scripts, redactor)
 		collectors = append(collectors, scriptExecutor)
 	} else {
 		log.Printf("No scripts configured")
@@ -78,6 +95,10 @@ func NewAgent(hostID string, interval time.Duration, reporter *Reporter, config
 		serviceCollector = NewServiceCollector(nil)
 	}
 	collectors = append(collectors, serviceCollector)
+	if len(config.ProcessRules) > 0 {
+		log.Printf("Loaded %d process rules from config", len(config.ProcessRules))
+		collectors = append(collectors, NewProcessRuleCollector(config.ProcessRules, processCollector))
+	}
 
 	return &Agent{
 		HostID:          hostID,
@@ -85,6 +106,7 @@ func NewAgent(hostID string, interval time.Duration, reporter *Reporter, config
 		reporter:        reporter,
 		collectors:      collectors,
 		logCollector:    logCollector,
+		fdTable:         fdTable,
 	}
 }
 
@@ -143,11 +165,18 @@ func (a *Agent) reportCoreMetrics(metrics *MetricsData) {
 		}
 	}
 
+	// systemd/端口检查和进程规则合并为一次服务状态上报，避免后一次上报覆盖前一次
+	services := &ServiceMetrics{Services: make([]ServiceInfo, 0)}
 	if serviceData, ok := metrics.Metrics["service"].(*ServiceMetrics); ok {
-		if len(serviceData.Services) > 0 {
-			if err := a.reporter.ReportServiceStatus(serviceData); err != nil {
-				log.Printf("Failed to report service status: %v", err)
-			}
+		services.Services = append(services.Services, serviceData.Services...)
+	}
+	if ruleData, ok := metrics.Metrics["process_rules"].(*ServiceMetrics); ok {
+		services.Services = append(services.Services, ruleData.Services...)
+	}
+	services.Count = len(services.Services)
+	if services.Count > 0 {
+		if err := a.reporter.ReportServiceStatus(services); err != nil {
+			log.Printf("Failed to report service status: %v", err)
 		}
 	}
 
@@ -171,16 +200,20 @@ func (a *Agent) collectAndReportLogs() {
 		return
 	}
 
-	// 上报日志
+	// 上报日志，成功后才确认读取位置，失败时下个周期重新读取
 	if logMetrics, ok := logData.(*LogMetrics); ok {
 		if len(logMetrics.Entries) > 0 {
 			log.Printf("Reporting %d log entries to server", len(logMetrics.Entries))
 			if err := a.reporter.ReportLogs(logMetrics); err != nil {
-				log.Printf("Failed to report logs: %v", err)
-			} else {
-				log.Printf("Successfully reported %d log entries", len(logMetrics.Entries))
+				// 未注册时不算失败，保留读取位置等注册后再上报
+				if !errors.Is(err, errReporterNotRegistered) {
+					log.Printf("Failed to report logs: %v", err)
+				}
+				return
 			}
+			log.Printf("Successfully reported %d log entries", len(logMetrics.Entries))
 		}
+		a.logCollector.Commit()
 	}
 }
 
@@ -191,6 +224,7 @@ func (a *Agent) collectMetrics() *MetricsData {
 		Metrics:   make(map[string]interface{}),
 	}
 
+	a.fdTable.reset()
 	for _, collector := range a.collectors {
 		metrics, err := collector.Collect()
 		if err != nil {
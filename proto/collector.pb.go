@@ -177,6 +177,13 @@ type MetricsRequest struct {
 	Disk          *DiskMetrics           `protobuf:"bytes,5,opt,name=disk,proto3" json:"disk,omitempty"`
 	Network       *NetworkMetrics        `protobuf:"bytes,6,opt,name=network,proto3" json:"network,omitempty"`
 	Gpu           *GPUMetrics            `protobuf:"bytes,7,opt,name=gpu,proto3" json:"gpu,omitempty"`
+	Cgroup        *CgroupMetrics         `protobuf:"bytes,8,opt,name=cgroup,proto3" json:"cgroup,omitempty"`
+	Pressure      *PressureMetrics       `protobuf:"bytes,9,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	Kernel        *KernelMetrics         `protobuf:"bytes,10,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	Netstack      *NetstackMetrics       `protobuf:"bytes,11,opt,name=netstack,proto3" json:"netstack,omitempty"`
+	Listeners     *ListenerMetrics       `protobuf:"bytes,12,opt,name=listeners,proto3" json:"listeners,omitempty"`
+	Limits        *LimitsMetrics         `protobuf:"bytes,13,opt,name=limits,proto3" json:"limits,omitempty"`
+	ProcessStates *ProcessStateMetrics   `protobuf:"bytes,14,opt,name=process_states,json=processStates,proto3" json:"process_states,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -260,16 +267,74 @@ func (x *MetricsRequest) GetGpu() *GPUMetrics {
 	return nil
 }
 
+func (x *MetricsRequest) GetCgroup() *CgroupMetrics {
+	if x != nil {
+		return x.Cgroup
+	}
+	return nil
+}
+
+func (x *MetricsRequest) GetPressure() *PressureMetrics {
+	if x != nil {
+		return x.Pressure
+	}
+	return nil
+}
+
+func (x *MetricsRequest) GetKernel() *KernelMetrics {
+	if x != nil {
+		return x.Kernel
+	}
+	return nil
+}
+
+func (x *MetricsRequest) GetNetstack() *NetstackMetrics {
+	if x != nil {
+		return x.Netstack
+	}
+	return nil
+}
+
+func (x *MetricsRequest) GetListeners() *ListenerMetrics {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *MetricsRequest) GetLimits() *LimitsMetrics {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *MetricsRequest) GetProcessStates() *ProcessStateMetrics {
+	if x != nil {
+		return x.ProcessStates
+	}
+	return nil
+}
+
 // CPU指标
 type CPUMetrics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UsagePercent  float64                `protobuf:"fixed64,1,opt,name=usage_percent,json=usagePercent,proto3" json:"usage_percent,omitempty"`
-	LoadAvg_1     float64                `protobuf:"fixed64,2,opt,name=load_avg_1,json=loadAvg1,proto3" json:"load_avg_1,omitempty"`
-	LoadAvg_5     float64                `protobuf:"fixed64,3,opt,name=load_avg_5,json=loadAvg5,proto3" json:"load_avg_5,omitempty"`
-	LoadAvg_15    float64                `protobuf:"fixed64,4,opt,name=load_avg_15,json=loadAvg15,proto3" json:"load_avg_15,omitempty"`
-	CoreCount     int32                  `protobuf:"varint,5,opt,name=core_count,json=coreCount,proto3" json:"core_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UsagePercent   float64                `protobuf:"fixed64,1,opt,name=usage_percent,json=usagePercent,proto3" json:"usage_percent,omitempty"`
+	LoadAvg_1      float64                `protobuf:"fixed64,2,opt,name=load_avg_1,json=loadAvg1,proto3" json:"load_avg_1,omitempty"`
+	LoadAvg_5      float64                `protobuf:"fixed64,3,opt,name=load_avg_5,json=loadAvg5,proto3" json:"load_avg_5,omitempty"`
+	LoadAvg_15     float64                `protobuf:"fixed64,4,opt,name=load_avg_15,json=loadAvg15,proto3" json:"load_avg_15,omitempty"`
+	CoreCount      int32                  `protobuf:"varint,5,opt,name=core_count,json=coreCount,proto3" json:"core_count,omitempty"`
+	PerCorePercent []float64              `protobuf:"fixed64,6,rep,packed,name=per_core_percent,json=perCorePercent,proto3" json:"per_core_percent,omitempty"` // 每个逻辑核使用率
+	UserPercent    float64                `protobuf:"fixed64,7,opt,name=user_percent,json=userPercent,proto3" json:"user_percent,omitempty"`
+	SystemPercent  float64                `protobuf:"fixed64,8,opt,name=system_percent,json=systemPercent,proto3" json:"system_percent,omitempty"`
+	NicePercent    float64                `protobuf:"fixed64,9,opt,name=nice_percent,json=nicePercent,proto3" json:"nice_percent,omitempty"`
+	IowaitPercent  float64                `protobuf:"fixed64,10,opt,name=iowait_percent,json=iowaitPercent,proto3" json:"iowait_percent,omitempty"`
+	IrqPercent     float64                `protobuf:"fixed64,11,opt,name=irq_percent,json=irqPercent,proto3" json:"irq_percent,omitempty"`
+	SoftirqPercent float64                `protobuf:"fixed64,12,opt,name=softirq_percent,json=softirqPercent,proto3" json:"softirq_percent,omitempty"`
+	StealPercent   float64                `protobuf:"fixed64,13,opt,name=steal_percent,json=stealPercent,proto3" json:"steal_percent,omitempty"`
+	GuestPercent   float64                `protobuf:"fixed64,14,opt,name=guest_percent,json=guestPercent,proto3" json:"guest_percent,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CPUMetrics) Reset() {
@@ -337,16 +402,99 @@ func (x *CPUMetrics) GetCoreCount() int32 {
 	return 0
 }
 
+func (x *CPUMetrics) GetPerCorePercent() []float64 {
+	if x != nil {
+		return x.PerCorePercent
+	}
+	return nil
+}
+
+func (x *CPUMetrics) GetUserPercent() float64 {
+	if x != nil {
+		return x.UserPercent
+	}
+	return 0
+}
+
+func (x *CPUMetrics) GetSystemPercent() float64 {
+	if x != nil {
+		return x.SystemPercent
+	}
+	return 0
+}
+
+func (x *CPUMetrics) GetNicePercent() float64 {
+	if x != nil {
+		return x.NicePercent
+	}
+	return 0
+}
+
+func (x *CPUMetrics) GetIowaitPercent() float64 {
+	if x != nil {
+		return x.IowaitPercent
+	}
+	return 0
+}
+
+func (x *CPUMetrics) GetIrqPercent() float64 {
+	if x != nil {
+		return x.IrqPercent
+	}
+	return 0
+}
+
+func (x *CPUMetrics) GetSoftirqPercent() float64 {
+	if x != nil {
+		return x.SoftirqPercent
+	}
+	return 0
+}
+
+func (x *CPUMetrics) GetStealPercent() float64 {
+	if x != nil {
+		return x.StealPercent
+	}
+	return 0
+}
+
+func (x *CPUMetrics) GetGuestPercent() float64 {
+	if x != nil {
+		return x.GuestPercent
+	}
+	return 0
+}
+
 // 内存指标
 type MemoryMetrics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Total         uint64                 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	Used          uint64                 `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
-	Free          uint64                 `protobuf:"varint,3,opt,name=free,proto3" json:"free,omitempty"`
-	UsedPercent   float64                `protobuf:"fixed64,4,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
-	Available     uint64                 `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Total                uint64                 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Used                 uint64                 `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	Free                 uint64                 `protobuf:"varint,3,opt,name=free,proto3" json:"free,omitempty"`
+	UsedPercent          float64                `protobuf:"fixed64,4,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	Available            uint64                 `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	SwapTotal            uint64                 `protobuf:"varint,6,opt,name=swap_total,json=swapTotal,proto3" json:"swap_total,omitempty"`
+	SwapUsed             uint64                 `protobuf:"varint,7,opt,name=swap_used,json=swapUsed,proto3" json:"swap_used,omitempty"`
+	SwapFree             uint64                 `protobuf:"varint,8,opt,name=swap_free,json=swapFree,proto3" json:"swap_free,omitempty"`
+	SwapUsedPercent      float64                `protobuf:"fixed64,9,opt,name=swap_used_percent,json=swapUsedPercent,proto3" json:"swap_used_percent,omitempty"`
+	Buffers              uint64                 `protobuf:"varint,10,opt,name=buffers,proto3" json:"buffers,omitempty"`
+	Cached               uint64                 `protobuf:"varint,11,opt,name=cached,proto3" json:"cached,omitempty"`
+	Shared               uint64                 `protobuf:"varint,12,opt,name=shared,proto3" json:"shared,omitempty"`
+	SlabReclaimable      uint64                 `protobuf:"varint,13,opt,name=slab_reclaimable,json=slabReclaimable,proto3" json:"slab_reclaimable,omitempty"`
+	SlabUnreclaimable    uint64                 `protobuf:"varint,14,opt,name=slab_unreclaimable,json=slabUnreclaimable,proto3" json:"slab_unreclaimable,omitempty"`
+	Dirty                uint64                 `protobuf:"varint,15,opt,name=dirty,proto3" json:"dirty,omitempty"`
+	Writeback            uint64                 `protobuf:"varint,16,opt,name=writeback,proto3" json:"writeback,omitempty"`
+	CommittedAs          uint64                 `protobuf:"varint,17,opt,name=committed_as,json=committedAs,proto3" json:"committed_as,omitempty"`
+	CommitLimit          uint64                 `protobuf:"varint,18,opt,name=commit_limit,json=commitLimit,proto3" json:"commit_limit,omitempty"`
+	CommitPercent        float64                `protobuf:"fixed64,19,opt,name=commit_percent,json=commitPercent,proto3" json:"commit_percent,omitempty"`     // committed_as / commit_limit
+	HugePagesTotal       uint64                 `protobuf:"varint,20,opt,name=huge_pages_total,json=hugePagesTotal,proto3" json:"huge_pages_total,omitempty"` // 大页数量（页）
+	HugePagesFree        uint64                 `protobuf:"varint,21,opt,name=huge_pages_free,json=hugePagesFree,proto3" json:"huge_pages_free,omitempty"`
+	HugePagesReserved    uint64                 `protobuf:"varint,22,opt,name=huge_pages_reserved,json=hugePagesReserved,proto3" json:"huge_pages_reserved,omitempty"`
+	HugePageSize         uint64                 `protobuf:"varint,23,opt,name=huge_page_size,json=hugePageSize,proto3" json:"huge_page_size,omitempty"` // 单个大页字节数
+	HugePagesUsedPercent float64                `protobuf:"fixed64,24,opt,name=huge_pages_used_percent,json=hugePagesUsedPercent,proto3" json:"huge_pages_used_percent,omitempty"`
+	AnonHugePages        uint64                 `protobuf:"varint,25,opt,name=anon_huge_pages,json=anonHugePages,proto3" json:"anon_huge_pages,omitempty"` // 透明大页占用字节数
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *MemoryMetrics) Reset() {
@@ -414,10 +562,151 @@ func (x *MemoryMetrics) GetAvailable() uint64 {
 	return 0
 }
 
+func (x *MemoryMetrics) GetSwapTotal() uint64 {
+	if x != nil {
+		return x.SwapTotal
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetSwapUsed() uint64 {
+	if x != nil {
+		return x.SwapUsed
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetSwapFree() uint64 {
+	if x != nil {
+		return x.SwapFree
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetSwapUsedPercent() float64 {
+	if x != nil {
+		return x.SwapUsedPercent
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetBuffers() uint64 {
+	if x != nil {
+		return x.Buffers
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetCached() uint64 {
+	if x != nil {
+		return x.Cached
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetShared() uint64 {
+	if x != nil {
+		return x.Shared
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetSlabReclaimable() uint64 {
+	if x != nil {
+		return x.SlabReclaimable
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetSlabUnreclaimable() uint64 {
+	if x != nil {
+		return x.SlabUnreclaimable
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetDirty() uint64 {
+	if x != nil {
+		return x.Dirty
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetWriteback() uint64 {
+	if x != nil {
+		return x.Writeback
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetCommittedAs() uint64 {
+	if x != nil {
+		return x.CommittedAs
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetCommitLimit() uint64 {
+	if x != nil {
+		return x.CommitLimit
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetCommitPercent() float64 {
+	if x != nil {
+		return x.CommitPercent
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetHugePagesTotal() uint64 {
+	if x != nil {
+		return x.HugePagesTotal
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetHugePagesFree() uint64 {
+	if x != nil {
+		return x.HugePagesFree
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetHugePagesReserved() uint64 {
+	if x != nil {
+		return x.HugePagesReserved
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetHugePageSize() uint64 {
+	if x != nil {
+		return x.HugePageSize
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetHugePagesUsedPercent() float64 {
+	if x != nil {
+		return x.HugePagesUsedPercent
+	}
+	return 0
+}
+
+func (x *MemoryMetrics) GetAnonHugePages() uint64 {
+	if x != nil {
+		return x.AnonHugePages
+	}
+	return 0
+}
+
 // 磁盘指标
 type DiskMetrics struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Partitions    []*PartitionMetrics    `protobuf:"bytes,1,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	Io            []*DiskIOMetrics       `protobuf:"bytes,2,rep,name=io,proto3" json:"io,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -459,18 +748,31 @@ func (x *DiskMetrics) GetPartitions() []*PartitionMetrics {
 	return nil
 }
 
+func (x *DiskMetrics) GetIo() []*DiskIOMetrics {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
 // 分区指标
 type PartitionMetrics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
-	Mountpoint    string                 `protobuf:"bytes,2,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
-	Fstype        string                 `protobuf:"bytes,3,opt,name=fstype,proto3" json:"fstype,omitempty"`
-	Total         uint64                 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
-	Used          uint64                 `protobuf:"varint,5,opt,name=used,proto3" json:"used,omitempty"`
-	Free          uint64                 `protobuf:"varint,6,opt,name=free,proto3" json:"free,omitempty"`
-	UsedPercent   float64                `protobuf:"fixed64,7,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Device               string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Mountpoint           string                 `protobuf:"bytes,2,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	Fstype               string                 `protobuf:"bytes,3,opt,name=fstype,proto3" json:"fstype,omitempty"`
+	Total                uint64                 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Used                 uint64                 `protobuf:"varint,5,opt,name=used,proto3" json:"used,omitempty"`
+	Free                 uint64                 `protobuf:"varint,6,opt,name=free,proto3" json:"free,omitempty"`
+	UsedPercent          float64                `protobuf:"fixed64,7,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	InodesTotal          uint64                 `protobuf:"varint,8,opt,name=inodes_total,json=inodesTotal,proto3" json:"inodes_total,omitempty"`
+	InodesUsed           uint64                 `protobuf:"varint,9,opt,name=inodes_used,json=inodesUsed,proto3" json:"inodes_used,omitempty"`
+	InodesFree           uint64                 `protobuf:"varint,10,opt,name=inodes_free,json=inodesFree,proto3" json:"inodes_free,omitempty"`
+	InodesUsedPercent    float64                `protobuf:"fixed64,11,opt,name=inodes_used_percent,json=inodesUsedPercent,proto3" json:"inodes_used_percent,omitempty"`
+	GrowthBytesPerSecond float64                `protobuf:"fixed64,12,opt,name=growth_bytes_per_second,json=growthBytesPerSecond,proto3" json:"growth_bytes_per_second,omitempty"` // 按历史趋势拟合的已用空间增长速率
+	SecondsUntilFull     int64                  `protobuf:"varint,13,opt,name=seconds_until_full,json=secondsUntilFull,proto3" json:"seconds_until_full,omitempty"`                // 预计写满秒数，-1 表示数据不足或未增长
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *PartitionMetrics) Reset() {
@@ -552,80 +854,281 @@ func (x *PartitionMetrics) GetUsedPercent() float64 {
 	return 0
 }
 
-// 网络指标
-type NetworkMetrics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Interfaces    []*InterfaceMetrics    `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *NetworkMetrics) Reset() {
-	*x = NetworkMetrics{}
-	mi := &file_proto_collector_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PartitionMetrics) GetInodesTotal() uint64 {
+	if x != nil {
+		return x.InodesTotal
+	}
+	return 0
 }
 
-func (x *NetworkMetrics) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PartitionMetrics) GetInodesUsed() uint64 {
+	if x != nil {
+		return x.InodesUsed
+	}
+	return 0
 }
 
-func (*NetworkMetrics) ProtoMessage() {}
-
-func (x *NetworkMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[7]
+func (x *PartitionMetrics) GetInodesFree() uint64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.InodesFree
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use NetworkMetrics.ProtoReflect.Descriptor instead.
-func (*NetworkMetrics) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{7}
+func (x *PartitionMetrics) GetInodesUsedPercent() float64 {
+	if x != nil {
+		return x.InodesUsedPercent
+	}
+	return 0
 }
 
-func (x *NetworkMetrics) GetInterfaces() []*InterfaceMetrics {
+func (x *PartitionMetrics) GetGrowthBytesPerSecond() float64 {
 	if x != nil {
-		return x.Interfaces
+		return x.GrowthBytesPerSecond
 	}
-	return nil
+	return 0
 }
 
-// 网卡指标
-type InterfaceMetrics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	BytesSent     uint64                 `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
-	BytesRecv     uint64                 `protobuf:"varint,3,opt,name=bytes_recv,json=bytesRecv,proto3" json:"bytes_recv,omitempty"`
-	PacketsSent   uint64                 `protobuf:"varint,4,opt,name=packets_sent,json=packetsSent,proto3" json:"packets_sent,omitempty"`
-	PacketsRecv   uint64                 `protobuf:"varint,5,opt,name=packets_recv,json=packetsRecv,proto3" json:"packets_recv,omitempty"`
-	Errin         uint64                 `protobuf:"varint,6,opt,name=errin,proto3" json:"errin,omitempty"`
-	Errout        uint64                 `protobuf:"varint,7,opt,name=errout,proto3" json:"errout,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PartitionMetrics) GetSecondsUntilFull() int64 {
+	if x != nil {
+		return x.SecondsUntilFull
+	}
+	return 0
 }
 
-func (x *InterfaceMetrics) Reset() {
-	*x = InterfaceMetrics{}
-	mi := &file_proto_collector_proto_msgTypes[8]
+// 块设备IO指标
+type DiskIOMetrics struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Device           string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"` // 内核设备名，如 sda、nvme0n1、dm-0
+	Label            string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`   // device-mapper 名称等标签
+	Mountpoints      []string               `protobuf:"bytes,3,rep,name=mountpoints,proto3" json:"mountpoints,omitempty"`
+	ReadBytesPerSec  float64                `protobuf:"fixed64,4,opt,name=read_bytes_per_sec,json=readBytesPerSec,proto3" json:"read_bytes_per_sec,omitempty"`
+	WriteBytesPerSec float64                `protobuf:"fixed64,5,opt,name=write_bytes_per_sec,json=writeBytesPerSec,proto3" json:"write_bytes_per_sec,omitempty"`
+	ReadIops         float64                `protobuf:"fixed64,6,opt,name=read_iops,json=readIops,proto3" json:"read_iops,omitempty"`
+	WriteIops        float64                `protobuf:"fixed64,7,opt,name=write_iops,json=writeIops,proto3" json:"write_iops,omitempty"`
+	ReadAwaitMs      float64                `protobuf:"fixed64,8,opt,name=read_await_ms,json=readAwaitMs,proto3" json:"read_await_ms,omitempty"`
+	WriteAwaitMs     float64                `protobuf:"fixed64,9,opt,name=write_await_ms,json=writeAwaitMs,proto3" json:"write_await_ms,omitempty"`
+	AwaitMs          float64                `protobuf:"fixed64,10,opt,name=await_ms,json=awaitMs,proto3" json:"await_ms,omitempty"`
+	QueueDepth       float64                `protobuf:"fixed64,11,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"` // 平均队列长度（aqu-sz）
+	UtilPercent      float64                `protobuf:"fixed64,12,opt,name=util_percent,json=utilPercent,proto3" json:"util_percent,omitempty"`
+	IopsInProgress   uint64                 `protobuf:"varint,13,opt,name=iops_in_progress,json=iopsInProgress,proto3" json:"iops_in_progress,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DiskIOMetrics) Reset() {
+	*x = DiskIOMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InterfaceMetrics) String() string {
+func (x *DiskIOMetrics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InterfaceMetrics) ProtoMessage() {}
+func (*DiskIOMetrics) ProtoMessage() {}
 
-func (x *InterfaceMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[8]
+func (x *DiskIOMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskIOMetrics.ProtoReflect.Descriptor instead.
+func (*DiskIOMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DiskIOMetrics) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DiskIOMetrics) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *DiskIOMetrics) GetMountpoints() []string {
+	if x != nil {
+		return x.Mountpoints
+	}
+	return nil
+}
+
+func (x *DiskIOMetrics) GetReadBytesPerSec() float64 {
+	if x != nil {
+		return x.ReadBytesPerSec
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetWriteBytesPerSec() float64 {
+	if x != nil {
+		return x.WriteBytesPerSec
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetReadIops() float64 {
+	if x != nil {
+		return x.ReadIops
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetWriteIops() float64 {
+	if x != nil {
+		return x.WriteIops
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetReadAwaitMs() float64 {
+	if x != nil {
+		return x.ReadAwaitMs
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetWriteAwaitMs() float64 {
+	if x != nil {
+		return x.WriteAwaitMs
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetAwaitMs() float64 {
+	if x != nil {
+		return x.AwaitMs
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetQueueDepth() float64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetUtilPercent() float64 {
+	if x != nil {
+		return x.UtilPercent
+	}
+	return 0
+}
+
+func (x *DiskIOMetrics) GetIopsInProgress() uint64 {
+	if x != nil {
+		return x.IopsInProgress
+	}
+	return 0
+}
+
+// 网络指标
+type NetworkMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interfaces    []*InterfaceMetrics    `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkMetrics) Reset() {
+	*x = NetworkMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkMetrics) ProtoMessage() {}
+
+func (x *NetworkMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkMetrics.ProtoReflect.Descriptor instead.
+func (*NetworkMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NetworkMetrics) GetInterfaces() []*InterfaceMetrics {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+// 网卡指标
+type InterfaceMetrics struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BytesSent   uint64                 `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesRecv   uint64                 `protobuf:"varint,3,opt,name=bytes_recv,json=bytesRecv,proto3" json:"bytes_recv,omitempty"`
+	PacketsSent uint64                 `protobuf:"varint,4,opt,name=packets_sent,json=packetsSent,proto3" json:"packets_sent,omitempty"`
+	PacketsRecv uint64                 `protobuf:"varint,5,opt,name=packets_recv,json=packetsRecv,proto3" json:"packets_recv,omitempty"`
+	Errin       uint64                 `protobuf:"varint,6,opt,name=errin,proto3" json:"errin,omitempty"`
+	Errout      uint64                 `protobuf:"varint,7,opt,name=errout,proto3" json:"errout,omitempty"`
+	Dropin      uint64                 `protobuf:"varint,8,opt,name=dropin,proto3" json:"dropin,omitempty"`
+	Dropout     uint64                 `protobuf:"varint,9,opt,name=dropout,proto3" json:"dropout,omitempty"`
+	// 每秒速率，已处理32位计数器回绕和计数器重置
+	BytesSentPerSec    float64  `protobuf:"fixed64,10,opt,name=bytes_sent_per_sec,json=bytesSentPerSec,proto3" json:"bytes_sent_per_sec,omitempty"`
+	BytesRecvPerSec    float64  `protobuf:"fixed64,11,opt,name=bytes_recv_per_sec,json=bytesRecvPerSec,proto3" json:"bytes_recv_per_sec,omitempty"`
+	PacketsSentPerSec  float64  `protobuf:"fixed64,12,opt,name=packets_sent_per_sec,json=packetsSentPerSec,proto3" json:"packets_sent_per_sec,omitempty"`
+	PacketsRecvPerSec  float64  `protobuf:"fixed64,13,opt,name=packets_recv_per_sec,json=packetsRecvPerSec,proto3" json:"packets_recv_per_sec,omitempty"`
+	ErrinPerSec        float64  `protobuf:"fixed64,14,opt,name=errin_per_sec,json=errinPerSec,proto3" json:"errin_per_sec,omitempty"`
+	ErroutPerSec       float64  `protobuf:"fixed64,15,opt,name=errout_per_sec,json=erroutPerSec,proto3" json:"errout_per_sec,omitempty"`
+	DropinPerSec       float64  `protobuf:"fixed64,16,opt,name=dropin_per_sec,json=dropinPerSec,proto3" json:"dropin_per_sec,omitempty"`
+	DropoutPerSec      float64  `protobuf:"fixed64,17,opt,name=dropout_per_sec,json=dropoutPerSec,proto3" json:"dropout_per_sec,omitempty"`
+	OperState          string   `protobuf:"bytes,18,opt,name=oper_state,json=operState,proto3" json:"oper_state,omitempty"`  // up、down、unknown 等
+	SpeedMbps          int64    `protobuf:"varint,19,opt,name=speed_mbps,json=speedMbps,proto3" json:"speed_mbps,omitempty"` // 链路速率，0 表示未知
+	Duplex             string   `protobuf:"bytes,20,opt,name=duplex,proto3" json:"duplex,omitempty"`
+	Mtu                int32    `protobuf:"varint,21,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	HardwareAddr       string   `protobuf:"bytes,22,opt,name=hardware_addr,json=hardwareAddr,proto3" json:"hardware_addr,omitempty"`
+	Addresses          []string `protobuf:"bytes,23,rep,name=addresses,proto3" json:"addresses,omitempty"`                                               // IPv4/IPv6 地址（CIDR）
+	UtilizationPercent float64  `protobuf:"fixed64,24,opt,name=utilization_percent,json=utilizationPercent,proto3" json:"utilization_percent,omitempty"` // 按链路速率计算的带宽利用率
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *InterfaceMetrics) Reset() {
+	*x = InterfaceMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceMetrics) ProtoMessage() {}
+
+func (x *InterfaceMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,7 +1141,7 @@ func (x *InterfaceMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InterfaceMetrics.ProtoReflect.Descriptor instead.
 func (*InterfaceMetrics) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{8}
+	return file_proto_collector_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *InterfaceMetrics) GetName() string {
@@ -690,6 +1193,125 @@ func (x *InterfaceMetrics) GetErrout() uint64 {
 	return 0
 }
 
+func (x *InterfaceMetrics) GetDropin() uint64 {
+	if x != nil {
+		return x.Dropin
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetDropout() uint64 {
+	if x != nil {
+		return x.Dropout
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetBytesSentPerSec() float64 {
+	if x != nil {
+		return x.BytesSentPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetBytesRecvPerSec() float64 {
+	if x != nil {
+		return x.BytesRecvPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetPacketsSentPerSec() float64 {
+	if x != nil {
+		return x.PacketsSentPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetPacketsRecvPerSec() float64 {
+	if x != nil {
+		return x.PacketsRecvPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetErrinPerSec() float64 {
+	if x != nil {
+		return x.ErrinPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetErroutPerSec() float64 {
+	if x != nil {
+		return x.ErroutPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetDropinPerSec() float64 {
+	if x != nil {
+		return x.DropinPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetDropoutPerSec() float64 {
+	if x != nil {
+		return x.DropoutPerSec
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetOperState() string {
+	if x != nil {
+		return x.OperState
+	}
+	return ""
+}
+
+func (x *InterfaceMetrics) GetSpeedMbps() int64 {
+	if x != nil {
+		return x.SpeedMbps
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetDuplex() string {
+	if x != nil {
+		return x.Duplex
+	}
+	return ""
+}
+
+func (x *InterfaceMetrics) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *InterfaceMetrics) GetHardwareAddr() string {
+	if x != nil {
+		return x.HardwareAddr
+	}
+	return ""
+}
+
+func (x *InterfaceMetrics) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *InterfaceMetrics) GetUtilizationPercent() float64 {
+	if x != nil {
+		return x.UtilizationPercent
+	}
+	return 0
+}
+
 type GPUMetrics struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Devices       []*GPUDeviceMetrics    `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
@@ -699,7 +1321,7 @@ type GPUMetrics struct {
 
 func (x *GPUMetrics) Reset() {
 	*x = GPUMetrics{}
-	mi := &file_proto_collector_proto_msgTypes[9]
+	mi := &file_proto_collector_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -711,7 +1333,7 @@ func (x *GPUMetrics) String() string {
 func (*GPUMetrics) ProtoMessage() {}
 
 func (x *GPUMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[9]
+	mi := &file_proto_collector_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,7 +1346,7 @@ func (x *GPUMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GPUMetrics.ProtoReflect.Descriptor instead.
 func (*GPUMetrics) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{9}
+	return file_proto_collector_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GPUMetrics) GetDevices() []*GPUDeviceMetrics {
@@ -755,7 +1377,7 @@ type GPUDeviceMetrics struct {
 
 func (x *GPUDeviceMetrics) Reset() {
 	*x = GPUDeviceMetrics{}
-	mi := &file_proto_collector_proto_msgTypes[10]
+	mi := &file_proto_collector_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -764,10 +1386,1475 @@ func (x *GPUDeviceMetrics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GPUDeviceMetrics) ProtoMessage() {}
+func (*GPUDeviceMetrics) ProtoMessage() {}
+
+func (x *GPUDeviceMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GPUDeviceMetrics.ProtoReflect.Descriptor instead.
+func (*GPUDeviceMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GPUDeviceMetrics) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *GPUDeviceMetrics) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GPUDeviceMetrics) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *GPUDeviceMetrics) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GPUDeviceMetrics) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GPUDeviceMetrics) GetDriverVersion() string {
+	if x != nil {
+		return x.DriverVersion
+	}
+	return ""
+}
+
+func (x *GPUDeviceMetrics) GetUtilizationPercent() float64 {
+	if x != nil {
+		return x.UtilizationPercent
+	}
+	return 0
+}
+
+func (x *GPUDeviceMetrics) GetMemoryTotal() uint64 {
+	if x != nil {
+		return x.MemoryTotal
+	}
+	return 0
+}
+
+func (x *GPUDeviceMetrics) GetMemoryUsed() uint64 {
+	if x != nil {
+		return x.MemoryUsed
+	}
+	return 0
+}
+
+func (x *GPUDeviceMetrics) GetMemoryUsedPercent() float64 {
+	if x != nil {
+		return x.MemoryUsedPercent
+	}
+	return 0
+}
+
+func (x *GPUDeviceMetrics) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *GPUDeviceMetrics) GetPowerWatts() float64 {
+	if x != nil {
+		return x.PowerWatts
+	}
+	return 0
+}
+
+func (x *GPUDeviceMetrics) GetFanSpeedPercent() float64 {
+	if x != nil {
+		return x.FanSpeedPercent
+	}
+	return 0
+}
+
+// cgroup v2 工作负载指标（systemd 服务、容器 scope）
+type CgroupMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supported     bool                   `protobuf:"varint,1,opt,name=supported,proto3" json:"supported,omitempty"`
+	Groups        []*CgroupInfo          `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CgroupMetrics) Reset() {
+	*x = CgroupMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CgroupMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CgroupMetrics) ProtoMessage() {}
+
+func (x *CgroupMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CgroupMetrics.ProtoReflect.Descriptor instead.
+func (*CgroupMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CgroupMetrics) GetSupported() bool {
+	if x != nil {
+		return x.Supported
+	}
+	return false
+}
+
+func (x *CgroupMetrics) GetGroups() []*CgroupInfo {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type CgroupInfo struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Path                    string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Kind                    string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // service 或 container
+	Name                    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ContainerId             string                 `protobuf:"bytes,4,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Unit                    string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	CpuUsageUsec            uint64                 `protobuf:"varint,6,opt,name=cpu_usage_usec,json=cpuUsageUsec,proto3" json:"cpu_usage_usec,omitempty"`
+	CpuThrottledUsec        uint64                 `protobuf:"varint,7,opt,name=cpu_throttled_usec,json=cpuThrottledUsec,proto3" json:"cpu_throttled_usec,omitempty"`
+	CpuPercent              float64                `protobuf:"fixed64,8,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemoryCurrent           uint64                 `protobuf:"varint,9,opt,name=memory_current,json=memoryCurrent,proto3" json:"memory_current,omitempty"`
+	MemoryMax               uint64                 `protobuf:"varint,10,opt,name=memory_max,json=memoryMax,proto3" json:"memory_max,omitempty"` // 0 表示不限制
+	MemoryPercent           float64                `protobuf:"fixed64,11,opt,name=memory_percent,json=memoryPercent,proto3" json:"memory_percent,omitempty"`
+	MemoryPressureSomeAvg10 float64                `protobuf:"fixed64,12,opt,name=memory_pressure_some_avg10,json=memoryPressureSomeAvg10,proto3" json:"memory_pressure_some_avg10,omitempty"`
+	MemoryPressureFullAvg10 float64                `protobuf:"fixed64,13,opt,name=memory_pressure_full_avg10,json=memoryPressureFullAvg10,proto3" json:"memory_pressure_full_avg10,omitempty"`
+	IoReadBytes             uint64                 `protobuf:"varint,14,opt,name=io_read_bytes,json=ioReadBytes,proto3" json:"io_read_bytes,omitempty"`
+	IoWriteBytes            uint64                 `protobuf:"varint,15,opt,name=io_write_bytes,json=ioWriteBytes,proto3" json:"io_write_bytes,omitempty"`
+	IoReadBytesPerSec       float64                `protobuf:"fixed64,16,opt,name=io_read_bytes_per_sec,json=ioReadBytesPerSec,proto3" json:"io_read_bytes_per_sec,omitempty"`
+	IoWriteBytesPerSec      float64                `protobuf:"fixed64,17,opt,name=io_write_bytes_per_sec,json=ioWriteBytesPerSec,proto3" json:"io_write_bytes_per_sec,omitempty"`
+	PidsCurrent             uint64                 `protobuf:"varint,18,opt,name=pids_current,json=pidsCurrent,proto3" json:"pids_current,omitempty"`
+	CpuPressureSomeAvg10    float64                `protobuf:"fixed64,19,opt,name=cpu_pressure_some_avg10,json=cpuPressureSomeAvg10,proto3" json:"cpu_pressure_some_avg10,omitempty"`
+	IoPressureSomeAvg10     float64                `protobuf:"fixed64,20,opt,name=io_pressure_some_avg10,json=ioPressureSomeAvg10,proto3" json:"io_pressure_some_avg10,omitempty"`
+	IoPressureFullAvg10     float64                `protobuf:"fixed64,21,opt,name=io_pressure_full_avg10,json=ioPressureFullAvg10,proto3" json:"io_pressure_full_avg10,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *CgroupInfo) Reset() {
+	*x = CgroupInfo{}
+	mi := &file_proto_collector_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CgroupInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CgroupInfo) ProtoMessage() {}
+
+func (x *CgroupInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CgroupInfo.ProtoReflect.Descriptor instead.
+func (*CgroupInfo) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CgroupInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CgroupInfo) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CgroupInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CgroupInfo) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *CgroupInfo) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *CgroupInfo) GetCpuUsageUsec() uint64 {
+	if x != nil {
+		return x.CpuUsageUsec
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetCpuThrottledUsec() uint64 {
+	if x != nil {
+		return x.CpuThrottledUsec
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetMemoryCurrent() uint64 {
+	if x != nil {
+		return x.MemoryCurrent
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetMemoryMax() uint64 {
+	if x != nil {
+		return x.MemoryMax
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetMemoryPercent() float64 {
+	if x != nil {
+		return x.MemoryPercent
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetMemoryPressureSomeAvg10() float64 {
+	if x != nil {
+		return x.MemoryPressureSomeAvg10
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetMemoryPressureFullAvg10() float64 {
+	if x != nil {
+		return x.MemoryPressureFullAvg10
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetIoReadBytes() uint64 {
+	if x != nil {
+		return x.IoReadBytes
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetIoWriteBytes() uint64 {
+	if x != nil {
+		return x.IoWriteBytes
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetIoReadBytesPerSec() float64 {
+	if x != nil {
+		return x.IoReadBytesPerSec
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetIoWriteBytesPerSec() float64 {
+	if x != nil {
+		return x.IoWriteBytesPerSec
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetPidsCurrent() uint64 {
+	if x != nil {
+		return x.PidsCurrent
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetCpuPressureSomeAvg10() float64 {
+	if x != nil {
+		return x.CpuPressureSomeAvg10
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetIoPressureSomeAvg10() float64 {
+	if x != nil {
+		return x.IoPressureSomeAvg10
+	}
+	return 0
+}
+
+func (x *CgroupInfo) GetIoPressureFullAvg10() float64 {
+	if x != nil {
+		return x.IoPressureFullAvg10
+	}
+	return 0
+}
+
+// Linux PSI 压力指标，内核不支持时 supported=false
+type PressureMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supported     bool                   `protobuf:"varint,1,opt,name=supported,proto3" json:"supported,omitempty"`
+	Cpu           *PressureResource      `protobuf:"bytes,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory        *PressureResource      `protobuf:"bytes,3,opt,name=memory,proto3" json:"memory,omitempty"`
+	Io            *PressureResource      `protobuf:"bytes,4,opt,name=io,proto3" json:"io,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressureMetrics) Reset() {
+	*x = PressureMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressureMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressureMetrics) ProtoMessage() {}
+
+func (x *PressureMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressureMetrics.ProtoReflect.Descriptor instead.
+func (*PressureMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PressureMetrics) GetSupported() bool {
+	if x != nil {
+		return x.Supported
+	}
+	return false
+}
+
+func (x *PressureMetrics) GetCpu() *PressureResource {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *PressureMetrics) GetMemory() *PressureResource {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *PressureMetrics) GetIo() *PressureResource {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+type PressureResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Some          *PressureStat          `protobuf:"bytes,1,opt,name=some,proto3" json:"some,omitempty"`
+	Full          *PressureStat          `protobuf:"bytes,2,opt,name=full,proto3" json:"full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressureResource) Reset() {
+	*x = PressureResource{}
+	mi := &file_proto_collector_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressureResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressureResource) ProtoMessage() {}
+
+func (x *PressureResource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressureResource.ProtoReflect.Descriptor instead.
+func (*PressureResource) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PressureResource) GetSome() *PressureStat {
+	if x != nil {
+		return x.Some
+	}
+	return nil
+}
+
+func (x *PressureResource) GetFull() *PressureStat {
+	if x != nil {
+		return x.Full
+	}
+	return nil
+}
+
+type PressureStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Avg10         float64                `protobuf:"fixed64,1,opt,name=avg10,proto3" json:"avg10,omitempty"`
+	Avg60         float64                `protobuf:"fixed64,2,opt,name=avg60,proto3" json:"avg60,omitempty"`
+	Avg300        float64                `protobuf:"fixed64,3,opt,name=avg300,proto3" json:"avg300,omitempty"`
+	TotalUsec     uint64                 `protobuf:"varint,4,opt,name=total_usec,json=totalUsec,proto3" json:"total_usec,omitempty"` // 累计停顿时间（微秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressureStat) Reset() {
+	*x = PressureStat{}
+	mi := &file_proto_collector_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressureStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressureStat) ProtoMessage() {}
+
+func (x *PressureStat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressureStat.ProtoReflect.Descriptor instead.
+func (*PressureStat) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PressureStat) GetAvg10() float64 {
+	if x != nil {
+		return x.Avg10
+	}
+	return 0
+}
+
+func (x *PressureStat) GetAvg60() float64 {
+	if x != nil {
+		return x.Avg60
+	}
+	return 0
+}
+
+func (x *PressureStat) GetAvg300() float64 {
+	if x != nil {
+		return x.Avg300
+	}
+	return 0
+}
+
+func (x *PressureStat) GetTotalUsec() uint64 {
+	if x != nil {
+		return x.TotalUsec
+	}
+	return 0
+}
+
+// 内核活动计数器（/proc/stat、/proc/vmstat），速率为每秒值
+type KernelMetrics struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ContextSwitchesPerSec float64                `protobuf:"fixed64,1,opt,name=context_switches_per_sec,json=contextSwitchesPerSec,proto3" json:"context_switches_per_sec,omitempty"`
+	InterruptsPerSec      float64                `protobuf:"fixed64,2,opt,name=interrupts_per_sec,json=interruptsPerSec,proto3" json:"interrupts_per_sec,omitempty"`
+	ForksPerSec           float64                `protobuf:"fixed64,3,opt,name=forks_per_sec,json=forksPerSec,proto3" json:"forks_per_sec,omitempty"`
+	ProcsRunning          uint64                 `protobuf:"varint,4,opt,name=procs_running,json=procsRunning,proto3" json:"procs_running,omitempty"`
+	ProcsBlocked          uint64                 `protobuf:"varint,5,opt,name=procs_blocked,json=procsBlocked,proto3" json:"procs_blocked,omitempty"`
+	PageFaultsPerSec      float64                `protobuf:"fixed64,6,opt,name=page_faults_per_sec,json=pageFaultsPerSec,proto3" json:"page_faults_per_sec,omitempty"`
+	MajorPageFaultsPerSec float64                `protobuf:"fixed64,7,opt,name=major_page_faults_per_sec,json=majorPageFaultsPerSec,proto3" json:"major_page_faults_per_sec,omitempty"`
+	SwapInPagesPerSec     float64                `protobuf:"fixed64,8,opt,name=swap_in_pages_per_sec,json=swapInPagesPerSec,proto3" json:"swap_in_pages_per_sec,omitempty"`
+	SwapOutPagesPerSec    float64                `protobuf:"fixed64,9,opt,name=swap_out_pages_per_sec,json=swapOutPagesPerSec,proto3" json:"swap_out_pages_per_sec,omitempty"`
+	PageScanKswapdPerSec  float64                `protobuf:"fixed64,10,opt,name=page_scan_kswapd_per_sec,json=pageScanKswapdPerSec,proto3" json:"page_scan_kswapd_per_sec,omitempty"`
+	PageScanDirectPerSec  float64                `protobuf:"fixed64,11,opt,name=page_scan_direct_per_sec,json=pageScanDirectPerSec,proto3" json:"page_scan_direct_per_sec,omitempty"`
+	PageStealPerSec       float64                `protobuf:"fixed64,12,opt,name=page_steal_per_sec,json=pageStealPerSec,proto3" json:"page_steal_per_sec,omitempty"`
+	OomKills              uint64                 `protobuf:"varint,13,opt,name=oom_kills,json=oomKills,proto3" json:"oom_kills,omitempty"`                  // 开机以来累计 OOM kill 次数
+	OomKillsDelta         uint64                 `protobuf:"varint,14,opt,name=oom_kills_delta,json=oomKillsDelta,proto3" json:"oom_kills_delta,omitempty"` // 本次采集周期新增次数
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *KernelMetrics) Reset() {
+	*x = KernelMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KernelMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelMetrics) ProtoMessage() {}
+
+func (x *KernelMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelMetrics.ProtoReflect.Descriptor instead.
+func (*KernelMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *KernelMetrics) GetContextSwitchesPerSec() float64 {
+	if x != nil {
+		return x.ContextSwitchesPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetInterruptsPerSec() float64 {
+	if x != nil {
+		return x.InterruptsPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetForksPerSec() float64 {
+	if x != nil {
+		return x.ForksPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetProcsRunning() uint64 {
+	if x != nil {
+		return x.ProcsRunning
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetProcsBlocked() uint64 {
+	if x != nil {
+		return x.ProcsBlocked
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetPageFaultsPerSec() float64 {
+	if x != nil {
+		return x.PageFaultsPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetMajorPageFaultsPerSec() float64 {
+	if x != nil {
+		return x.MajorPageFaultsPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetSwapInPagesPerSec() float64 {
+	if x != nil {
+		return x.SwapInPagesPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetSwapOutPagesPerSec() float64 {
+	if x != nil {
+		return x.SwapOutPagesPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetPageScanKswapdPerSec() float64 {
+	if x != nil {
+		return x.PageScanKswapdPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetPageScanDirectPerSec() float64 {
+	if x != nil {
+		return x.PageScanDirectPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetPageStealPerSec() float64 {
+	if x != nil {
+		return x.PageStealPerSec
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetOomKills() uint64 {
+	if x != nil {
+		return x.OomKills
+	}
+	return 0
+}
+
+func (x *KernelMetrics) GetOomKillsDelta() uint64 {
+	if x != nil {
+		return x.OomKillsDelta
+	}
+	return 0
+}
+
+// 协议栈状态（/proc/net/tcp、snmp、netstat），速率为每秒值
+type NetstackMetrics struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	TcpStates              map[string]uint64      `protobuf:"bytes,1,rep,name=tcp_states,json=tcpStates,proto3" json:"tcp_states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 按 TCP 状态统计的连接数，IPv4 和 IPv6 合计
+	TcpSockets             uint64                 `protobuf:"varint,2,opt,name=tcp_sockets,json=tcpSockets,proto3" json:"tcp_sockets,omitempty"`
+	UdpSockets             uint64                 `protobuf:"varint,3,opt,name=udp_sockets,json=udpSockets,proto3" json:"udp_sockets,omitempty"`
+	TcpCurrEstab           uint64                 `protobuf:"varint,4,opt,name=tcp_curr_estab,json=tcpCurrEstab,proto3" json:"tcp_curr_estab,omitempty"`
+	TcpActiveOpensPerSec   float64                `protobuf:"fixed64,5,opt,name=tcp_active_opens_per_sec,json=tcpActiveOpensPerSec,proto3" json:"tcp_active_opens_per_sec,omitempty"`
+	TcpPassiveOpensPerSec  float64                `protobuf:"fixed64,6,opt,name=tcp_passive_opens_per_sec,json=tcpPassiveOpensPerSec,proto3" json:"tcp_passive_opens_per_sec,omitempty"`
+	TcpAttemptFailsPerSec  float64                `protobuf:"fixed64,7,opt,name=tcp_attempt_fails_per_sec,json=tcpAttemptFailsPerSec,proto3" json:"tcp_attempt_fails_per_sec,omitempty"`
+	TcpEstabResetsPerSec   float64                `protobuf:"fixed64,8,opt,name=tcp_estab_resets_per_sec,json=tcpEstabResetsPerSec,proto3" json:"tcp_estab_resets_per_sec,omitempty"`
+	TcpInErrsPerSec        float64                `protobuf:"fixed64,9,opt,name=tcp_in_errs_per_sec,json=tcpInErrsPerSec,proto3" json:"tcp_in_errs_per_sec,omitempty"`
+	TcpOutRstsPerSec       float64                `protobuf:"fixed64,10,opt,name=tcp_out_rsts_per_sec,json=tcpOutRstsPerSec,proto3" json:"tcp_out_rsts_per_sec,omitempty"`
+	TcpOutSegsPerSec       float64                `protobuf:"fixed64,11,opt,name=tcp_out_segs_per_sec,json=tcpOutSegsPerSec,proto3" json:"tcp_out_segs_per_sec,omitempty"`
+	TcpRetransSegsPerSec   float64                `protobuf:"fixed64,12,opt,name=tcp_retrans_segs_per_sec,json=tcpRetransSegsPerSec,proto3" json:"tcp_retrans_segs_per_sec,omitempty"`
+	TcpRetransPercent      float64                `protobuf:"fixed64,13,opt,name=tcp_retrans_percent,json=tcpRetransPercent,proto3" json:"tcp_retrans_percent,omitempty"` // 重传段占发送段比例
+	ListenOverflowsPerSec  float64                `protobuf:"fixed64,14,opt,name=listen_overflows_per_sec,json=listenOverflowsPerSec,proto3" json:"listen_overflows_per_sec,omitempty"`
+	ListenDropsPerSec      float64                `protobuf:"fixed64,15,opt,name=listen_drops_per_sec,json=listenDropsPerSec,proto3" json:"listen_drops_per_sec,omitempty"`
+	SyncookiesSentPerSec   float64                `protobuf:"fixed64,16,opt,name=syncookies_sent_per_sec,json=syncookiesSentPerSec,proto3" json:"syncookies_sent_per_sec,omitempty"`
+	SyncookiesRecvPerSec   float64                `protobuf:"fixed64,17,opt,name=syncookies_recv_per_sec,json=syncookiesRecvPerSec,proto3" json:"syncookies_recv_per_sec,omitempty"`
+	SyncookiesFailedPerSec float64                `protobuf:"fixed64,18,opt,name=syncookies_failed_per_sec,json=syncookiesFailedPerSec,proto3" json:"syncookies_failed_per_sec,omitempty"`
+	UdpInErrorsPerSec      float64                `protobuf:"fixed64,19,opt,name=udp_in_errors_per_sec,json=udpInErrorsPerSec,proto3" json:"udp_in_errors_per_sec,omitempty"` // UDP 计数器为 IPv4 和 IPv6 合计
+	UdpNoPortsPerSec       float64                `protobuf:"fixed64,20,opt,name=udp_no_ports_per_sec,json=udpNoPortsPerSec,proto3" json:"udp_no_ports_per_sec,omitempty"`
+	UdpRcvbufErrorsPerSec  float64                `protobuf:"fixed64,21,opt,name=udp_rcvbuf_errors_per_sec,json=udpRcvbufErrorsPerSec,proto3" json:"udp_rcvbuf_errors_per_sec,omitempty"`
+	UdpSndbufErrorsPerSec  float64                `protobuf:"fixed64,22,opt,name=udp_sndbuf_errors_per_sec,json=udpSndbufErrorsPerSec,proto3" json:"udp_sndbuf_errors_per_sec,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *NetstackMetrics) Reset() {
+	*x = NetstackMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetstackMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetstackMetrics) ProtoMessage() {}
+
+func (x *NetstackMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetstackMetrics.ProtoReflect.Descriptor instead.
+func (*NetstackMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NetstackMetrics) GetTcpStates() map[string]uint64 {
+	if x != nil {
+		return x.TcpStates
+	}
+	return nil
+}
+
+func (x *NetstackMetrics) GetTcpSockets() uint64 {
+	if x != nil {
+		return x.TcpSockets
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetUdpSockets() uint64 {
+	if x != nil {
+		return x.UdpSockets
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpCurrEstab() uint64 {
+	if x != nil {
+		return x.TcpCurrEstab
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpActiveOpensPerSec() float64 {
+	if x != nil {
+		return x.TcpActiveOpensPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpPassiveOpensPerSec() float64 {
+	if x != nil {
+		return x.TcpPassiveOpensPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpAttemptFailsPerSec() float64 {
+	if x != nil {
+		return x.TcpAttemptFailsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpEstabResetsPerSec() float64 {
+	if x != nil {
+		return x.TcpEstabResetsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpInErrsPerSec() float64 {
+	if x != nil {
+		return x.TcpInErrsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpOutRstsPerSec() float64 {
+	if x != nil {
+		return x.TcpOutRstsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpOutSegsPerSec() float64 {
+	if x != nil {
+		return x.TcpOutSegsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpRetransSegsPerSec() float64 {
+	if x != nil {
+		return x.TcpRetransSegsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetTcpRetransPercent() float64 {
+	if x != nil {
+		return x.TcpRetransPercent
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetListenOverflowsPerSec() float64 {
+	if x != nil {
+		return x.ListenOverflowsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetListenDropsPerSec() float64 {
+	if x != nil {
+		return x.ListenDropsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetSyncookiesSentPerSec() float64 {
+	if x != nil {
+		return x.SyncookiesSentPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetSyncookiesRecvPerSec() float64 {
+	if x != nil {
+		return x.SyncookiesRecvPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetSyncookiesFailedPerSec() float64 {
+	if x != nil {
+		return x.SyncookiesFailedPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetUdpInErrorsPerSec() float64 {
+	if x != nil {
+		return x.UdpInErrorsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetUdpNoPortsPerSec() float64 {
+	if x != nil {
+		return x.UdpNoPortsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetUdpRcvbufErrorsPerSec() float64 {
+	if x != nil {
+		return x.UdpRcvbufErrorsPerSec
+	}
+	return 0
+}
+
+func (x *NetstackMetrics) GetUdpSndbufErrorsPerSec() float64 {
+	if x != nil {
+		return x.UdpSndbufErrorsPerSec
+	}
+	return 0
+}
+
+// 监听端口清单，events 只包含本次采集相对上一次的变化
+type ListenerMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sockets       []*ListeningSocket     `protobuf:"bytes,1,rep,name=sockets,proto3" json:"sockets,omitempty"`
+	Events        []*ListenerEvent       `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenerMetrics) Reset() {
+	*x = ListenerMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerMetrics) ProtoMessage() {}
+
+func (x *ListenerMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerMetrics.ProtoReflect.Descriptor instead.
+func (*ListenerMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListenerMetrics) GetSockets() []*ListeningSocket {
+	if x != nil {
+		return x.Sockets
+	}
+	return nil
+}
+
+func (x *ListenerMetrics) GetEvents() []*ListenerEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ListeningSocket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Protocol      string                 `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"` // tcp、tcp6、udp、udp6
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Pid           int32                  `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"` // 0 表示无法确定所属进程
+	Process       string                 `protobuf:"bytes,5,opt,name=process,proto3" json:"process,omitempty"`
+	Uid           int32                  `protobuf:"varint,6,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListeningSocket) Reset() {
+	*x = ListeningSocket{}
+	mi := &file_proto_collector_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListeningSocket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListeningSocket) ProtoMessage() {}
+
+func (x *ListeningSocket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListeningSocket.ProtoReflect.Descriptor instead.
+func (*ListeningSocket) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListeningSocket) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ListeningSocket) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListeningSocket) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ListeningSocket) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ListeningSocket) GetProcess() string {
+	if x != nil {
+		return x.Process
+	}
+	return ""
+}
+
+func (x *ListeningSocket) GetUid() int32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type ListenerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // new：新增监听；gone：监听消失
+	Socket        *ListeningSocket       `protobuf:"bytes,2,opt,name=socket,proto3" json:"socket,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenerEvent) Reset() {
+	*x = ListenerEvent{}
+	mi := &file_proto_collector_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerEvent) ProtoMessage() {}
+
+func (x *ListenerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerEvent.ProtoReflect.Descriptor instead.
+func (*ListenerEvent) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListenerEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListenerEvent) GetSocket() *ListeningSocket {
+	if x != nil {
+		return x.Socket
+	}
+	return nil
+}
+
+func (x *ListenerEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// conntrack 表、全局文件句柄和进程打开文件数使用情况
+type LimitsMetrics struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ConntrackSupported   bool                   `protobuf:"varint,1,opt,name=conntrack_supported,json=conntrackSupported,proto3" json:"conntrack_supported,omitempty"` // false 表示未加载 nf_conntrack 模块
+	ConntrackCount       uint64                 `protobuf:"varint,2,opt,name=conntrack_count,json=conntrackCount,proto3" json:"conntrack_count,omitempty"`
+	ConntrackMax         uint64                 `protobuf:"varint,3,opt,name=conntrack_max,json=conntrackMax,proto3" json:"conntrack_max,omitempty"`
+	ConntrackPercent     float64                `protobuf:"fixed64,4,opt,name=conntrack_percent,json=conntrackPercent,proto3" json:"conntrack_percent,omitempty"`
+	FileHandlesAllocated uint64                 `protobuf:"varint,5,opt,name=file_handles_allocated,json=fileHandlesAllocated,proto3" json:"file_handles_allocated,omitempty"`
+	FileHandlesMax       uint64                 `protobuf:"varint,6,opt,name=file_handles_max,json=fileHandlesMax,proto3" json:"file_handles_max,omitempty"`
+	FileHandlesPercent   float64                `protobuf:"fixed64,7,opt,name=file_handles_percent,json=fileHandlesPercent,proto3" json:"file_handles_percent,omitempty"`
+	Processes            []*ProcessFDUsage      `protobuf:"bytes,8,rep,name=processes,proto3" json:"processes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *LimitsMetrics) Reset() {
+	*x = LimitsMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitsMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitsMetrics) ProtoMessage() {}
+
+func (x *LimitsMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LimitsMetrics.ProtoReflect.Descriptor instead.
+func (*LimitsMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LimitsMetrics) GetConntrackSupported() bool {
+	if x != nil {
+		return x.ConntrackSupported
+	}
+	return false
+}
+
+func (x *LimitsMetrics) GetConntrackCount() uint64 {
+	if x != nil {
+		return x.ConntrackCount
+	}
+	return 0
+}
+
+func (x *LimitsMetrics) GetConntrackMax() uint64 {
+	if x != nil {
+		return x.ConntrackMax
+	}
+	return 0
+}
+
+func (x *LimitsMetrics) GetConntrackPercent() float64 {
+	if x != nil {
+		return x.ConntrackPercent
+	}
+	return 0
+}
+
+func (x *LimitsMetrics) GetFileHandlesAllocated() uint64 {
+	if x != nil {
+		return x.FileHandlesAllocated
+	}
+	return 0
+}
+
+func (x *LimitsMetrics) GetFileHandlesMax() uint64 {
+	if x != nil {
+		return x.FileHandlesMax
+	}
+	return 0
+}
+
+func (x *LimitsMetrics) GetFileHandlesPercent() float64 {
+	if x != nil {
+		return x.FileHandlesPercent
+	}
+	return 0
+}
+
+func (x *LimitsMetrics) GetProcesses() []*ProcessFDUsage {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+type ProcessFDUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OpenFds       uint64                 `protobuf:"varint,3,opt,name=open_fds,json=openFds,proto3" json:"open_fds,omitempty"`
+	SoftLimit     uint64                 `protobuf:"varint,4,opt,name=soft_limit,json=softLimit,proto3" json:"soft_limit,omitempty"` // RLIMIT_NOFILE，0 表示 unlimited
+	HardLimit     uint64                 `protobuf:"varint,5,opt,name=hard_limit,json=hardLimit,proto3" json:"hard_limit,omitempty"`
+	Percent       float64                `protobuf:"fixed64,6,opt,name=percent,proto3" json:"percent,omitempty"` // 打开文件数占软限制比例
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessFDUsage) Reset() {
+	*x = ProcessFDUsage{}
+	mi := &file_proto_collector_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessFDUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessFDUsage) ProtoMessage() {}
+
+func (x *ProcessFDUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessFDUsage.ProtoReflect.Descriptor instead.
+func (*ProcessFDUsage) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProcessFDUsage) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcessFDUsage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProcessFDUsage) GetOpenFds() uint64 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+func (x *ProcessFDUsage) GetSoftLimit() uint64 {
+	if x != nil {
+		return x.SoftLimit
+	}
+	return 0
+}
+
+func (x *ProcessFDUsage) GetHardLimit() uint64 {
+	if x != nil {
+		return x.HardLimit
+	}
+	return 0
+}
+
+func (x *ProcessFDUsage) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+// 全机进程状态汇总
+type ProcessStateMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	States        map[string]int32       `protobuf:"bytes,2,rep,name=states,proto3" json:"states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // R、S、D、Z、T、I 等状态的进程数
+	Zombies       []*ZombieProcess       `protobuf:"bytes,3,rep,name=zombies,proto3" json:"zombies,omitempty"`
+	StuckDState   []*StuckProcess        `protobuf:"bytes,4,rep,name=stuck_d_state,json=stuckDState,proto3" json:"stuck_d_state,omitempty"`  // 持续处于 D 状态超过阈值
+	LeakSuspects  []*LeakSuspect         `protobuf:"bytes,5,rep,name=leak_suspects,json=leakSuspects,proto3" json:"leak_suspects,omitempty"` // RSS 连续多个周期持续增长
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessStateMetrics) Reset() {
+	*x = ProcessStateMetrics{}
+	mi := &file_proto_collector_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessStateMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessStateMetrics) ProtoMessage() {}
+
+func (x *ProcessStateMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessStateMetrics.ProtoReflect.Descriptor instead.
+func (*ProcessStateMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ProcessStateMetrics) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ProcessStateMetrics) GetStates() map[string]int32 {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ProcessStateMetrics) GetZombies() []*ZombieProcess {
+	if x != nil {
+		return x.Zombies
+	}
+	return nil
+}
+
+func (x *ProcessStateMetrics) GetStuckDState() []*StuckProcess {
+	if x != nil {
+		return x.StuckDState
+	}
+	return nil
+}
+
+func (x *ProcessStateMetrics) GetLeakSuspects() []*LeakSuspect {
+	if x != nil {
+		return x.LeakSuspects
+	}
+	return nil
+}
+
+type ZombieProcess struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ppid          int32                  `protobuf:"varint,3,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	ParentName    string                 `protobuf:"bytes,4,opt,name=parent_name,json=parentName,proto3" json:"parent_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZombieProcess) Reset() {
+	*x = ZombieProcess{}
+	mi := &file_proto_collector_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZombieProcess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZombieProcess) ProtoMessage() {}
+
+func (x *ZombieProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZombieProcess.ProtoReflect.Descriptor instead.
+func (*ZombieProcess) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ZombieProcess) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ZombieProcess) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ZombieProcess) GetPpid() int32 {
+	if x != nil {
+		return x.Ppid
+	}
+	return 0
+}
+
+func (x *ZombieProcess) GetParentName() string {
+	if x != nil {
+		return x.ParentName
+	}
+	return ""
+}
+
+type StuckProcess struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Seconds       int64                  `protobuf:"varint,3,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StuckProcess) Reset() {
+	*x = StuckProcess{}
+	mi := &file_proto_collector_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StuckProcess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StuckProcess) ProtoMessage() {}
 
-func (x *GPUDeviceMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[10]
+func (x *StuckProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -778,98 +2865,104 @@ func (x *GPUDeviceMetrics) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GPUDeviceMetrics.ProtoReflect.Descriptor instead.
-func (*GPUDeviceMetrics) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use StuckProcess.ProtoReflect.Descriptor instead.
+func (*StuckProcess) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GPUDeviceMetrics) GetIndex() int32 {
+func (x *StuckProcess) GetPid() int32 {
 	if x != nil {
-		return x.Index
+		return x.Pid
 	}
 	return 0
 }
 
-func (x *GPUDeviceMetrics) GetName() string {
+func (x *StuckProcess) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *GPUDeviceMetrics) GetVendor() string {
+func (x *StuckProcess) GetSeconds() int64 {
 	if x != nil {
-		return x.Vendor
+		return x.Seconds
 	}
-	return ""
+	return 0
 }
 
-func (x *GPUDeviceMetrics) GetModel() string {
-	if x != nil {
-		return x.Model
-	}
-	return ""
+type LeakSuspect struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RssBytes      uint64                 `protobuf:"varint,3,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	GrowthBytes   uint64                 `protobuf:"varint,4,opt,name=growth_bytes,json=growthBytes,proto3" json:"growth_bytes,omitempty"`
+	Samples       int32                  `protobuf:"varint,5,opt,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GPUDeviceMetrics) GetUuid() string {
-	if x != nil {
-		return x.Uuid
-	}
-	return ""
+func (x *LeakSuspect) Reset() {
+	*x = LeakSuspect{}
+	mi := &file_proto_collector_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GPUDeviceMetrics) GetDriverVersion() string {
-	if x != nil {
-		return x.DriverVersion
-	}
-	return ""
+func (x *LeakSuspect) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GPUDeviceMetrics) GetUtilizationPercent() float64 {
+func (*LeakSuspect) ProtoMessage() {}
+
+func (x *LeakSuspect) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[27]
 	if x != nil {
-		return x.UtilizationPercent
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *GPUDeviceMetrics) GetMemoryTotal() uint64 {
-	if x != nil {
-		return x.MemoryTotal
-	}
-	return 0
+// Deprecated: Use LeakSuspect.ProtoReflect.Descriptor instead.
+func (*LeakSuspect) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *GPUDeviceMetrics) GetMemoryUsed() uint64 {
+func (x *LeakSuspect) GetPid() int32 {
 	if x != nil {
-		return x.MemoryUsed
+		return x.Pid
 	}
 	return 0
 }
 
-func (x *GPUDeviceMetrics) GetMemoryUsedPercent() float64 {
+func (x *LeakSuspect) GetName() string {
 	if x != nil {
-		return x.MemoryUsedPercent
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *GPUDeviceMetrics) GetTemperature() float64 {
+func (x *LeakSuspect) GetRssBytes() uint64 {
 	if x != nil {
-		return x.Temperature
+		return x.RssBytes
 	}
 	return 0
 }
 
-func (x *GPUDeviceMetrics) GetPowerWatts() float64 {
+func (x *LeakSuspect) GetGrowthBytes() uint64 {
 	if x != nil {
-		return x.PowerWatts
+		return x.GrowthBytes
 	}
 	return 0
 }
 
-func (x *GPUDeviceMetrics) GetFanSpeedPercent() float64 {
+func (x *LeakSuspect) GetSamples() int32 {
 	if x != nil {
-		return x.FanSpeedPercent
+		return x.Samples
 	}
 	return 0
 }
@@ -885,7 +2978,7 @@ type MetricsResponse struct {
 
 func (x *MetricsResponse) Reset() {
 	*x = MetricsResponse{}
-	mi := &file_proto_collector_proto_msgTypes[11]
+	mi := &file_proto_collector_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -897,7 +2990,7 @@ func (x *MetricsResponse) String() string {
 func (*MetricsResponse) ProtoMessage() {}
 
 func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[11]
+	mi := &file_proto_collector_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,7 +3003,7 @@ func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsResponse.ProtoReflect.Descriptor instead.
 func (*MetricsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{11}
+	return file_proto_collector_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *MetricsResponse) GetSuccess() bool {
@@ -938,7 +3031,7 @@ type HeartbeatRequest struct {
 
 func (x *HeartbeatRequest) Reset() {
 	*x = HeartbeatRequest{}
-	mi := &file_proto_collector_proto_msgTypes[12]
+	mi := &file_proto_collector_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -950,7 +3043,7 @@ func (x *HeartbeatRequest) String() string {
 func (*HeartbeatRequest) ProtoMessage() {}
 
 func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[12]
+	mi := &file_proto_collector_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +3056,7 @@ func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
 func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{12}
+	return file_proto_collector_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *HeartbeatRequest) GetHostId() string {
@@ -991,7 +3084,7 @@ type HeartbeatResponse struct {
 
 func (x *HeartbeatResponse) Reset() {
 	*x = HeartbeatResponse{}
-	mi := &file_proto_collector_proto_msgTypes[13]
+	mi := &file_proto_collector_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1003,7 +3096,7 @@ func (x *HeartbeatResponse) String() string {
 func (*HeartbeatResponse) ProtoMessage() {}
 
 func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[13]
+	mi := &file_proto_collector_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +3109,7 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{13}
+	return file_proto_collector_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *HeartbeatResponse) GetSuccess() bool {
@@ -1039,13 +3132,14 @@ type ProcessReportRequest struct {
 	HostId        string                 `protobuf:"bytes,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
 	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Processes     []*ProcessInfo         `protobuf:"bytes,3,rep,name=processes,proto3" json:"processes,omitempty"`
+	Groups        []*ProcessGroup        `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"` // 覆盖所有进程的分组汇总
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProcessReportRequest) Reset() {
 	*x = ProcessReportRequest{}
-	mi := &file_proto_collector_proto_msgTypes[14]
+	mi := &file_proto_collector_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1057,7 +3151,7 @@ func (x *ProcessReportRequest) String() string {
 func (*ProcessReportRequest) ProtoMessage() {}
 
 func (x *ProcessReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[14]
+	mi := &file_proto_collector_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,7 +3164,7 @@ func (x *ProcessReportRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessReportRequest.ProtoReflect.Descriptor instead.
 func (*ProcessReportRequest) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{14}
+	return file_proto_collector_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ProcessReportRequest) GetHostId() string {
@@ -1094,25 +3188,129 @@ func (x *ProcessReportRequest) GetProcesses() []*ProcessInfo {
 	return nil
 }
 
-// 进程信息
-type ProcessInfo struct {
+func (x *ProcessReportRequest) GetGroups() []*ProcessGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// 进程分组汇总
+type ProcessGroup struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	By            string                 `protobuf:"bytes,1,opt,name=by,proto3" json:"by,omitempty"` // name、user、workload（容器ID或 systemd unit）
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 	CpuPercent    float64                `protobuf:"fixed64,4,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
-	MemoryPercent float64                `protobuf:"fixed64,5,opt,name=memory_percent,json=memoryPercent,proto3" json:"memory_percent,omitempty"`
-	MemoryBytes   uint64                 `protobuf:"varint,6,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
-	CreateTime    int64                  `protobuf:"varint,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
-	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	Command       string                 `protobuf:"bytes,9,opt,name=command,proto3" json:"command,omitempty"`
+	MemoryBytes   uint64                 `protobuf:"varint,5,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"` // RSS 合计
+	NumFds        int64                  `protobuf:"varint,6,opt,name=num_fds,json=numFds,proto3" json:"num_fds,omitempty"`                // 打开的文件描述符合计
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ProcessGroup) Reset() {
+	*x = ProcessGroup{}
+	mi := &file_proto_collector_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessGroup) ProtoMessage() {}
+
+func (x *ProcessGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collector_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessGroup.ProtoReflect.Descriptor instead.
+func (*ProcessGroup) Descriptor() ([]byte, []int) {
+	return file_proto_collector_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ProcessGroup) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+func (x *ProcessGroup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ProcessGroup) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetMemoryBytes() uint64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *ProcessGroup) GetNumFds() int64 {
+	if x != nil {
+		return x.NumFds
+	}
+	return 0
+}
+
+// 进程信息
+type ProcessInfo struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Pid                    int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name                   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	User                   string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	CpuPercent             float64                `protobuf:"fixed64,4,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemoryPercent          float64                `protobuf:"fixed64,5,opt,name=memory_percent,json=memoryPercent,proto3" json:"memory_percent,omitempty"`
+	MemoryBytes            uint64                 `protobuf:"varint,6,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	CreateTime             int64                  `protobuf:"varint,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	Status                 string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Command                string                 `protobuf:"bytes,9,opt,name=command,proto3" json:"command,omitempty"`
+	Watched                bool                   `protobuf:"varint,10,opt,name=watched,proto3" json:"watched,omitempty"` // 命中关注列表，不论排名都会上报
+	Ppid                   int32                  `protobuf:"varint,11,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	NumThreads             int32                  `protobuf:"varint,12,opt,name=num_threads,json=numThreads,proto3" json:"num_threads,omitempty"`
+	NumFds                 int32                  `protobuf:"varint,13,opt,name=num_fds,json=numFds,proto3" json:"num_fds,omitempty"`
+	ReadBytesPerSec        float64                `protobuf:"fixed64,14,opt,name=read_bytes_per_sec,json=readBytesPerSec,proto3" json:"read_bytes_per_sec,omitempty"`
+	WriteBytesPerSec       float64                `protobuf:"fixed64,15,opt,name=write_bytes_per_sec,json=writeBytesPerSec,proto3" json:"write_bytes_per_sec,omitempty"`
+	VoluntaryCtxSwitches   int64                  `protobuf:"varint,16,opt,name=voluntary_ctx_switches,json=voluntaryCtxSwitches,proto3" json:"voluntary_ctx_switches,omitempty"`       // 累计值
+	InvoluntaryCtxSwitches int64                  `protobuf:"varint,17,opt,name=involuntary_ctx_switches,json=involuntaryCtxSwitches,proto3" json:"involuntary_ctx_switches,omitempty"` // 累计值
+	Nice                   int32                  `protobuf:"varint,18,opt,name=nice,proto3" json:"nice,omitempty"`
+	CgroupPath             string                 `protobuf:"bytes,19,opt,name=cgroup_path,json=cgroupPath,proto3" json:"cgroup_path,omitempty"`
+	ContainerId            string                 `protobuf:"bytes,20,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"` // 由 cgroup 路径解析，非容器进程为空
+	Unit                   string                 `protobuf:"bytes,21,opt,name=unit,proto3" json:"unit,omitempty"`                                  // systemd service unit
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
 func (x *ProcessInfo) Reset() {
 	*x = ProcessInfo{}
-	mi := &file_proto_collector_proto_msgTypes[15]
+	mi := &file_proto_collector_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1124,7 +3322,7 @@ func (x *ProcessInfo) String() string {
 func (*ProcessInfo) ProtoMessage() {}
 
 func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[15]
+	mi := &file_proto_collector_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1137,7 +3335,7 @@ func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessInfo.ProtoReflect.Descriptor instead.
 func (*ProcessInfo) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{15}
+	return file_proto_collector_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ProcessInfo) GetPid() int32 {
@@ -1203,6 +3401,90 @@ func (x *ProcessInfo) GetCommand() string {
 	return ""
 }
 
+func (x *ProcessInfo) GetWatched() bool {
+	if x != nil {
+		return x.Watched
+	}
+	return false
+}
+
+func (x *ProcessInfo) GetPpid() int32 {
+	if x != nil {
+		return x.Ppid
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetNumThreads() int32 {
+	if x != nil {
+		return x.NumThreads
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetNumFds() int32 {
+	if x != nil {
+		return x.NumFds
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetReadBytesPerSec() float64 {
+	if x != nil {
+		return x.ReadBytesPerSec
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetWriteBytesPerSec() float64 {
+	if x != nil {
+		return x.WriteBytesPerSec
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetVoluntaryCtxSwitches() int64 {
+	if x != nil {
+		return x.VoluntaryCtxSwitches
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetInvoluntaryCtxSwitches() int64 {
+	if x != nil {
+		return x.InvoluntaryCtxSwitches
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetNice() int32 {
+	if x != nil {
+		return x.Nice
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *ProcessInfo) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *ProcessInfo) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
 // 日志上报请求
 type LogReportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1215,7 +3497,7 @@ type LogReportRequest struct {
 
 func (x *LogReportRequest) Reset() {
 	*x = LogReportRequest{}
-	mi := &file_proto_collector_proto_msgTypes[16]
+	mi := &file_proto_collector_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1227,7 +3509,7 @@ func (x *LogReportRequest) String() string {
 func (*LogReportRequest) ProtoMessage() {}
 
 func (x *LogReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[16]
+	mi := &file_proto_collector_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1240,7 +3522,7 @@ func (x *LogReportRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogReportRequest.ProtoReflect.Descriptor instead.
 func (*LogReportRequest) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{16}
+	return file_proto_collector_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *LogReportRequest) GetHostId() string {
@@ -1278,7 +3560,7 @@ type LogEntry struct {
 
 func (x *LogEntry) Reset() {
 	*x = LogEntry{}
-	mi := &file_proto_collector_proto_msgTypes[17]
+	mi := &file_proto_collector_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1290,7 +3572,7 @@ func (x *LogEntry) String() string {
 func (*LogEntry) ProtoMessage() {}
 
 func (x *LogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[17]
+	mi := &file_proto_collector_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1303,7 +3585,7 @@ func (x *LogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
 func (*LogEntry) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{17}
+	return file_proto_collector_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *LogEntry) GetSource() string {
@@ -1359,7 +3641,7 @@ type ScriptResultRequest struct {
 
 func (x *ScriptResultRequest) Reset() {
 	*x = ScriptResultRequest{}
-	mi := &file_proto_collector_proto_msgTypes[18]
+	mi := &file_proto_collector_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1371,7 +3653,7 @@ func (x *ScriptResultRequest) String() string {
 func (*ScriptResultRequest) ProtoMessage() {}
 
 func (x *ScriptResultRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[18]
+	mi := &file_proto_collector_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,7 +3666,7 @@ func (x *ScriptResultRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScriptResultRequest.ProtoReflect.Descriptor instead.
 func (*ScriptResultRequest) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{18}
+	return file_proto_collector_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ScriptResultRequest) GetHostId() string {
@@ -1462,7 +3744,7 @@ type ServiceStatusRequest struct {
 
 func (x *ServiceStatusRequest) Reset() {
 	*x = ServiceStatusRequest{}
-	mi := &file_proto_collector_proto_msgTypes[19]
+	mi := &file_proto_collector_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1474,7 +3756,7 @@ func (x *ServiceStatusRequest) String() string {
 func (*ServiceStatusRequest) ProtoMessage() {}
 
 func (x *ServiceStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[19]
+	mi := &file_proto_collector_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1487,7 +3769,7 @@ func (x *ServiceStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceStatusRequest.ProtoReflect.Descriptor instead.
 func (*ServiceStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{19}
+	return file_proto_collector_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ServiceStatusRequest) GetHostId() string {
@@ -1521,13 +3803,16 @@ type ServiceInfo struct {
 	UptimeSeconds  int64                  `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`    // 运行时长（秒）
 	Port           int32                  `protobuf:"varint,6,opt,name=port,proto3" json:"port,omitempty"`                                           // 服务端口（可选，用于端口检查）
 	PortAccessible bool                   `protobuf:"varint,7,opt,name=port_accessible,json=portAccessible,proto3" json:"port_accessible,omitempty"` // 端口是否可访问（可选，用于端口检查结果）
+	Source         string                 `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`                                        // 来源：为空表示 systemd/端口检查，process_rule 表示进程规则
+	Instances      int32                  `protobuf:"varint,9,opt,name=instances,proto3" json:"instances,omitempty"`                                 // 进程规则匹配的实例数
+	Pids           []int32                `protobuf:"varint,10,rep,packed,name=pids,proto3" json:"pids,omitempty"`                                   // 进程规则匹配的 PID
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ServiceInfo) Reset() {
 	*x = ServiceInfo{}
-	mi := &file_proto_collector_proto_msgTypes[20]
+	mi := &file_proto_collector_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1539,7 +3824,7 @@ func (x *ServiceInfo) String() string {
 func (*ServiceInfo) ProtoMessage() {}
 
 func (x *ServiceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_collector_proto_msgTypes[20]
+	mi := &file_proto_collector_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1552,7 +3837,7 @@ func (x *ServiceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceInfo.ProtoReflect.Descriptor instead.
 func (*ServiceInfo) Descriptor() ([]byte, []int) {
-	return file_proto_collector_proto_rawDescGZIP(), []int{20}
+	return file_proto_collector_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ServiceInfo) GetName() string {
@@ -1604,6 +3889,27 @@ func (x *ServiceInfo) GetPortAccessible() bool {
 	return false
 }
 
+func (x *ServiceInfo) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ServiceInfo) GetInstances() int32 {
+	if x != nil {
+		return x.Instances
+	}
+	return 0
+}
+
+func (x *ServiceInfo) GetPids() []int32 {
+	if x != nil {
+		return x.Pids
+	}
+	return nil
+}
+
 var File_proto_collector_proto protoreflect.FileDescriptor
 
 const file_proto_collector_proto_rawDesc = "" +
@@ -1622,7 +3928,7 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\x10RegisterResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
-	"\x10collect_interval\x18\x03 \x01(\x03R\x0fcollectInterval\"\xac\x02\n" +
+	"\x10collect_interval\x18\x03 \x01(\x03R\x0fcollectInterval\"\xb3\x05\n" +
 	"\x0eMetricsRequest\x12\x17\n" +
 	"\ahost_id\x18\x01 \x01(\tR\x06hostId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12'\n" +
@@ -1630,7 +3936,15 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\x06memory\x18\x04 \x01(\v2\x18.collector.MemoryMetricsR\x06memory\x12*\n" +
 	"\x04disk\x18\x05 \x01(\v2\x16.collector.DiskMetricsR\x04disk\x123\n" +
 	"\anetwork\x18\x06 \x01(\v2\x19.collector.NetworkMetricsR\anetwork\x12'\n" +
-	"\x03gpu\x18\a \x01(\v2\x15.collector.GPUMetricsR\x03gpu\"\xac\x01\n" +
+	"\x03gpu\x18\a \x01(\v2\x15.collector.GPUMetricsR\x03gpu\x120\n" +
+	"\x06cgroup\x18\b \x01(\v2\x18.collector.CgroupMetricsR\x06cgroup\x126\n" +
+	"\bpressure\x18\t \x01(\v2\x1a.collector.PressureMetricsR\bpressure\x120\n" +
+	"\x06kernel\x18\n" +
+	" \x01(\v2\x18.collector.KernelMetricsR\x06kernel\x126\n" +
+	"\bnetstack\x18\v \x01(\v2\x1a.collector.NetstackMetricsR\bnetstack\x128\n" +
+	"\tlisteners\x18\f \x01(\v2\x1a.collector.ListenerMetricsR\tlisteners\x120\n" +
+	"\x06limits\x18\r \x01(\v2\x18.collector.LimitsMetricsR\x06limits\x12E\n" +
+	"\x0eprocess_states\x18\x0e \x01(\v2\x1e.collector.ProcessStateMetricsR\rprocessStates\"\xfe\x03\n" +
 	"\n" +
 	"CPUMetrics\x12#\n" +
 	"\rusage_percent\x18\x01 \x01(\x01R\fusagePercent\x12\x1c\n" +
@@ -1640,17 +3954,51 @@ const file_proto_collector_proto_rawDesc = "" +
 	"load_avg_5\x18\x03 \x01(\x01R\bloadAvg5\x12\x1e\n" +
 	"\vload_avg_15\x18\x04 \x01(\x01R\tloadAvg15\x12\x1d\n" +
 	"\n" +
-	"core_count\x18\x05 \x01(\x05R\tcoreCount\"\x8e\x01\n" +
+	"core_count\x18\x05 \x01(\x05R\tcoreCount\x12(\n" +
+	"\x10per_core_percent\x18\x06 \x03(\x01R\x0eperCorePercent\x12!\n" +
+	"\fuser_percent\x18\a \x01(\x01R\vuserPercent\x12%\n" +
+	"\x0esystem_percent\x18\b \x01(\x01R\rsystemPercent\x12!\n" +
+	"\fnice_percent\x18\t \x01(\x01R\vnicePercent\x12%\n" +
+	"\x0eiowait_percent\x18\n" +
+	" \x01(\x01R\riowaitPercent\x12\x1f\n" +
+	"\virq_percent\x18\v \x01(\x01R\n" +
+	"irqPercent\x12'\n" +
+	"\x0fsoftirq_percent\x18\f \x01(\x01R\x0esoftirqPercent\x12#\n" +
+	"\rsteal_percent\x18\r \x01(\x01R\fstealPercent\x12#\n" +
+	"\rguest_percent\x18\x0e \x01(\x01R\fguestPercent\"\xdf\x06\n" +
 	"\rMemoryMetrics\x12\x14\n" +
 	"\x05total\x18\x01 \x01(\x04R\x05total\x12\x12\n" +
 	"\x04used\x18\x02 \x01(\x04R\x04used\x12\x12\n" +
 	"\x04free\x18\x03 \x01(\x04R\x04free\x12!\n" +
 	"\fused_percent\x18\x04 \x01(\x01R\vusedPercent\x12\x1c\n" +
-	"\tavailable\x18\x05 \x01(\x04R\tavailable\"J\n" +
+	"\tavailable\x18\x05 \x01(\x04R\tavailable\x12\x1d\n" +
+	"\n" +
+	"swap_total\x18\x06 \x01(\x04R\tswapTotal\x12\x1b\n" +
+	"\tswap_used\x18\a \x01(\x04R\bswapUsed\x12\x1b\n" +
+	"\tswap_free\x18\b \x01(\x04R\bswapFree\x12*\n" +
+	"\x11swap_used_percent\x18\t \x01(\x01R\x0fswapUsedPercent\x12\x18\n" +
+	"\abuffers\x18\n" +
+	" \x01(\x04R\abuffers\x12\x16\n" +
+	"\x06cached\x18\v \x01(\x04R\x06cached\x12\x16\n" +
+	"\x06shared\x18\f \x01(\x04R\x06shared\x12)\n" +
+	"\x10slab_reclaimable\x18\r \x01(\x04R\x0fslabReclaimable\x12-\n" +
+	"\x12slab_unreclaimable\x18\x0e \x01(\x04R\x11slabUnreclaimable\x12\x14\n" +
+	"\x05dirty\x18\x0f \x01(\x04R\x05dirty\x12\x1c\n" +
+	"\twriteback\x18\x10 \x01(\x04R\twriteback\x12!\n" +
+	"\fcommitted_as\x18\x11 \x01(\x04R\vcommittedAs\x12!\n" +
+	"\fcommit_limit\x18\x12 \x01(\x04R\vcommitLimit\x12%\n" +
+	"\x0ecommit_percent\x18\x13 \x01(\x01R\rcommitPercent\x12(\n" +
+	"\x10huge_pages_total\x18\x14 \x01(\x04R\x0ehugePagesTotal\x12&\n" +
+	"\x0fhuge_pages_free\x18\x15 \x01(\x04R\rhugePagesFree\x12.\n" +
+	"\x13huge_pages_reserved\x18\x16 \x01(\x04R\x11hugePagesReserved\x12$\n" +
+	"\x0ehuge_page_size\x18\x17 \x01(\x04R\fhugePageSize\x125\n" +
+	"\x17huge_pages_used_percent\x18\x18 \x01(\x01R\x14hugePagesUsedPercent\x12&\n" +
+	"\x0fanon_huge_pages\x18\x19 \x01(\x04R\ranonHugePages\"t\n" +
 	"\vDiskMetrics\x12;\n" +
 	"\n" +
 	"partitions\x18\x01 \x03(\v2\x1b.collector.PartitionMetricsR\n" +
-	"partitions\"\xc3\x01\n" +
+	"partitions\x12(\n" +
+	"\x02io\x18\x02 \x03(\v2\x18.collector.DiskIOMetricsR\x02io\"\xbd\x03\n" +
 	"\x10PartitionMetrics\x12\x16\n" +
 	"\x06device\x18\x01 \x01(\tR\x06device\x12\x1e\n" +
 	"\n" +
@@ -1660,11 +4008,37 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\x05total\x18\x04 \x01(\x04R\x05total\x12\x12\n" +
 	"\x04used\x18\x05 \x01(\x04R\x04used\x12\x12\n" +
 	"\x04free\x18\x06 \x01(\x04R\x04free\x12!\n" +
-	"\fused_percent\x18\a \x01(\x01R\vusedPercent\"M\n" +
+	"\fused_percent\x18\a \x01(\x01R\vusedPercent\x12!\n" +
+	"\finodes_total\x18\b \x01(\x04R\vinodesTotal\x12\x1f\n" +
+	"\vinodes_used\x18\t \x01(\x04R\n" +
+	"inodesUsed\x12\x1f\n" +
+	"\vinodes_free\x18\n" +
+	" \x01(\x04R\n" +
+	"inodesFree\x12.\n" +
+	"\x13inodes_used_percent\x18\v \x01(\x01R\x11inodesUsedPercent\x125\n" +
+	"\x17growth_bytes_per_second\x18\f \x01(\x01R\x14growthBytesPerSecond\x12,\n" +
+	"\x12seconds_until_full\x18\r \x01(\x03R\x10secondsUntilFull\"\xca\x03\n" +
+	"\rDiskIOMetrics\x12\x16\n" +
+	"\x06device\x18\x01 \x01(\tR\x06device\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12 \n" +
+	"\vmountpoints\x18\x03 \x03(\tR\vmountpoints\x12+\n" +
+	"\x12read_bytes_per_sec\x18\x04 \x01(\x01R\x0freadBytesPerSec\x12-\n" +
+	"\x13write_bytes_per_sec\x18\x05 \x01(\x01R\x10writeBytesPerSec\x12\x1b\n" +
+	"\tread_iops\x18\x06 \x01(\x01R\breadIops\x12\x1d\n" +
+	"\n" +
+	"write_iops\x18\a \x01(\x01R\twriteIops\x12\"\n" +
+	"\rread_await_ms\x18\b \x01(\x01R\vreadAwaitMs\x12$\n" +
+	"\x0ewrite_await_ms\x18\t \x01(\x01R\fwriteAwaitMs\x12\x19\n" +
+	"\bawait_ms\x18\n" +
+	" \x01(\x01R\aawaitMs\x12\x1f\n" +
+	"\vqueue_depth\x18\v \x01(\x01R\n" +
+	"queueDepth\x12!\n" +
+	"\futil_percent\x18\f \x01(\x01R\vutilPercent\x12(\n" +
+	"\x10iops_in_progress\x18\r \x01(\x04R\x0eiopsInProgress\"M\n" +
 	"\x0eNetworkMetrics\x12;\n" +
 	"\n" +
 	"interfaces\x18\x01 \x03(\v2\x1b.collector.InterfaceMetricsR\n" +
-	"interfaces\"\xd8\x01\n" +
+	"interfaces\"\xba\x06\n" +
 	"\x10InterfaceMetrics\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
@@ -1674,7 +4048,27 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\fpackets_sent\x18\x04 \x01(\x04R\vpacketsSent\x12!\n" +
 	"\fpackets_recv\x18\x05 \x01(\x04R\vpacketsRecv\x12\x14\n" +
 	"\x05errin\x18\x06 \x01(\x04R\x05errin\x12\x16\n" +
-	"\x06errout\x18\a \x01(\x04R\x06errout\"C\n" +
+	"\x06errout\x18\a \x01(\x04R\x06errout\x12\x16\n" +
+	"\x06dropin\x18\b \x01(\x04R\x06dropin\x12\x18\n" +
+	"\adropout\x18\t \x01(\x04R\adropout\x12+\n" +
+	"\x12bytes_sent_per_sec\x18\n" +
+	" \x01(\x01R\x0fbytesSentPerSec\x12+\n" +
+	"\x12bytes_recv_per_sec\x18\v \x01(\x01R\x0fbytesRecvPerSec\x12/\n" +
+	"\x14packets_sent_per_sec\x18\f \x01(\x01R\x11packetsSentPerSec\x12/\n" +
+	"\x14packets_recv_per_sec\x18\r \x01(\x01R\x11packetsRecvPerSec\x12\"\n" +
+	"\rerrin_per_sec\x18\x0e \x01(\x01R\verrinPerSec\x12$\n" +
+	"\x0eerrout_per_sec\x18\x0f \x01(\x01R\ferroutPerSec\x12$\n" +
+	"\x0edropin_per_sec\x18\x10 \x01(\x01R\fdropinPerSec\x12&\n" +
+	"\x0fdropout_per_sec\x18\x11 \x01(\x01R\rdropoutPerSec\x12\x1d\n" +
+	"\n" +
+	"oper_state\x18\x12 \x01(\tR\toperState\x12\x1d\n" +
+	"\n" +
+	"speed_mbps\x18\x13 \x01(\x03R\tspeedMbps\x12\x16\n" +
+	"\x06duplex\x18\x14 \x01(\tR\x06duplex\x12\x10\n" +
+	"\x03mtu\x18\x15 \x01(\x05R\x03mtu\x12#\n" +
+	"\rhardware_addr\x18\x16 \x01(\tR\fhardwareAddr\x12\x1c\n" +
+	"\taddresses\x18\x17 \x03(\tR\taddresses\x12/\n" +
+	"\x13utilization_percent\x18\x18 \x01(\x01R\x12utilizationPercent\"C\n" +
 	"\n" +
 	"GPUMetrics\x125\n" +
 	"\adevices\x18\x01 \x03(\v2\x1b.collector.GPUDeviceMetricsR\adevices\"\xb9\x03\n" +
@@ -1694,7 +4088,153 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\vtemperature\x18\v \x01(\x01R\vtemperature\x12\x1f\n" +
 	"\vpower_watts\x18\f \x01(\x01R\n" +
 	"powerWatts\x12*\n" +
-	"\x11fan_speed_percent\x18\r \x01(\x01R\x0ffanSpeedPercent\"E\n" +
+	"\x11fan_speed_percent\x18\r \x01(\x01R\x0ffanSpeedPercent\"\\\n" +
+	"\rCgroupMetrics\x12\x1c\n" +
+	"\tsupported\x18\x01 \x01(\bR\tsupported\x12-\n" +
+	"\x06groups\x18\x02 \x03(\v2\x15.collector.CgroupInfoR\x06groups\"\xcf\x06\n" +
+	"\n" +
+	"CgroupInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12!\n" +
+	"\fcontainer_id\x18\x04 \x01(\tR\vcontainerId\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12$\n" +
+	"\x0ecpu_usage_usec\x18\x06 \x01(\x04R\fcpuUsageUsec\x12,\n" +
+	"\x12cpu_throttled_usec\x18\a \x01(\x04R\x10cpuThrottledUsec\x12\x1f\n" +
+	"\vcpu_percent\x18\b \x01(\x01R\n" +
+	"cpuPercent\x12%\n" +
+	"\x0ememory_current\x18\t \x01(\x04R\rmemoryCurrent\x12\x1d\n" +
+	"\n" +
+	"memory_max\x18\n" +
+	" \x01(\x04R\tmemoryMax\x12%\n" +
+	"\x0ememory_percent\x18\v \x01(\x01R\rmemoryPercent\x12;\n" +
+	"\x1amemory_pressure_some_avg10\x18\f \x01(\x01R\x17memoryPressureSomeAvg10\x12;\n" +
+	"\x1amemory_pressure_full_avg10\x18\r \x01(\x01R\x17memoryPressureFullAvg10\x12\"\n" +
+	"\rio_read_bytes\x18\x0e \x01(\x04R\vioReadBytes\x12$\n" +
+	"\x0eio_write_bytes\x18\x0f \x01(\x04R\fioWriteBytes\x120\n" +
+	"\x15io_read_bytes_per_sec\x18\x10 \x01(\x01R\x11ioReadBytesPerSec\x122\n" +
+	"\x16io_write_bytes_per_sec\x18\x11 \x01(\x01R\x12ioWriteBytesPerSec\x12!\n" +
+	"\fpids_current\x18\x12 \x01(\x04R\vpidsCurrent\x125\n" +
+	"\x17cpu_pressure_some_avg10\x18\x13 \x01(\x01R\x14cpuPressureSomeAvg10\x123\n" +
+	"\x16io_pressure_some_avg10\x18\x14 \x01(\x01R\x13ioPressureSomeAvg10\x123\n" +
+	"\x16io_pressure_full_avg10\x18\x15 \x01(\x01R\x13ioPressureFullAvg10\"\xc0\x01\n" +
+	"\x0fPressureMetrics\x12\x1c\n" +
+	"\tsupported\x18\x01 \x01(\bR\tsupported\x12-\n" +
+	"\x03cpu\x18\x02 \x01(\v2\x1b.collector.PressureResourceR\x03cpu\x123\n" +
+	"\x06memory\x18\x03 \x01(\v2\x1b.collector.PressureResourceR\x06memory\x12+\n" +
+	"\x02io\x18\x04 \x01(\v2\x1b.collector.PressureResourceR\x02io\"l\n" +
+	"\x10PressureResource\x12+\n" +
+	"\x04some\x18\x01 \x01(\v2\x17.collector.PressureStatR\x04some\x12+\n" +
+	"\x04full\x18\x02 \x01(\v2\x17.collector.PressureStatR\x04full\"q\n" +
+	"\fPressureStat\x12\x14\n" +
+	"\x05avg10\x18\x01 \x01(\x01R\x05avg10\x12\x14\n" +
+	"\x05avg60\x18\x02 \x01(\x01R\x05avg60\x12\x16\n" +
+	"\x06avg300\x18\x03 \x01(\x01R\x06avg300\x12\x1d\n" +
+	"\n" +
+	"total_usec\x18\x04 \x01(\x04R\ttotalUsec\"\x95\x05\n" +
+	"\rKernelMetrics\x127\n" +
+	"\x18context_switches_per_sec\x18\x01 \x01(\x01R\x15contextSwitchesPerSec\x12,\n" +
+	"\x12interrupts_per_sec\x18\x02 \x01(\x01R\x10interruptsPerSec\x12\"\n" +
+	"\rforks_per_sec\x18\x03 \x01(\x01R\vforksPerSec\x12#\n" +
+	"\rprocs_running\x18\x04 \x01(\x04R\fprocsRunning\x12#\n" +
+	"\rprocs_blocked\x18\x05 \x01(\x04R\fprocsBlocked\x12-\n" +
+	"\x13page_faults_per_sec\x18\x06 \x01(\x01R\x10pageFaultsPerSec\x128\n" +
+	"\x19major_page_faults_per_sec\x18\a \x01(\x01R\x15majorPageFaultsPerSec\x120\n" +
+	"\x15swap_in_pages_per_sec\x18\b \x01(\x01R\x11swapInPagesPerSec\x122\n" +
+	"\x16swap_out_pages_per_sec\x18\t \x01(\x01R\x12swapOutPagesPerSec\x126\n" +
+	"\x18page_scan_kswapd_per_sec\x18\n" +
+	" \x01(\x01R\x14pageScanKswapdPerSec\x126\n" +
+	"\x18page_scan_direct_per_sec\x18\v \x01(\x01R\x14pageScanDirectPerSec\x12+\n" +
+	"\x12page_steal_per_sec\x18\f \x01(\x01R\x0fpageStealPerSec\x12\x1b\n" +
+	"\toom_kills\x18\r \x01(\x04R\boomKills\x12&\n" +
+	"\x0foom_kills_delta\x18\x0e \x01(\x04R\roomKillsDelta\"\xc4\t\n" +
+	"\x0fNetstackMetrics\x12H\n" +
+	"\n" +
+	"tcp_states\x18\x01 \x03(\v2).collector.NetstackMetrics.TcpStatesEntryR\ttcpStates\x12\x1f\n" +
+	"\vtcp_sockets\x18\x02 \x01(\x04R\n" +
+	"tcpSockets\x12\x1f\n" +
+	"\vudp_sockets\x18\x03 \x01(\x04R\n" +
+	"udpSockets\x12$\n" +
+	"\x0etcp_curr_estab\x18\x04 \x01(\x04R\ftcpCurrEstab\x126\n" +
+	"\x18tcp_active_opens_per_sec\x18\x05 \x01(\x01R\x14tcpActiveOpensPerSec\x128\n" +
+	"\x19tcp_passive_opens_per_sec\x18\x06 \x01(\x01R\x15tcpPassiveOpensPerSec\x128\n" +
+	"\x19tcp_attempt_fails_per_sec\x18\a \x01(\x01R\x15tcpAttemptFailsPerSec\x126\n" +
+	"\x18tcp_estab_resets_per_sec\x18\b \x01(\x01R\x14tcpEstabResetsPerSec\x12,\n" +
+	"\x13tcp_in_errs_per_sec\x18\t \x01(\x01R\x0ftcpInErrsPerSec\x12.\n" +
+	"\x14tcp_out_rsts_per_sec\x18\n" +
+	" \x01(\x01R\x10tcpOutRstsPerSec\x12.\n" +
+	"\x14tcp_out_segs_per_sec\x18\v \x01(\x01R\x10tcpOutSegsPerSec\x126\n" +
+	"\x18tcp_retrans_segs_per_sec\x18\f \x01(\x01R\x14tcpRetransSegsPerSec\x12.\n" +
+	"\x13tcp_retrans_percent\x18\r \x01(\x01R\x11tcpRetransPercent\x127\n" +
+	"\x18listen_overflows_per_sec\x18\x0e \x01(\x01R\x15listenOverflowsPerSec\x12/\n" +
+	"\x14listen_drops_per_sec\x18\x0f \x01(\x01R\x11listenDropsPerSec\x125\n" +
+	"\x17syncookies_sent_per_sec\x18\x10 \x01(\x01R\x14syncookiesSentPerSec\x125\n" +
+	"\x17syncookies_recv_per_sec\x18\x11 \x01(\x01R\x14syncookiesRecvPerSec\x129\n" +
+	"\x19syncookies_failed_per_sec\x18\x12 \x01(\x01R\x16syncookiesFailedPerSec\x120\n" +
+	"\x15udp_in_errors_per_sec\x18\x13 \x01(\x01R\x11udpInErrorsPerSec\x12.\n" +
+	"\x14udp_no_ports_per_sec\x18\x14 \x01(\x01R\x10udpNoPortsPerSec\x128\n" +
+	"\x19udp_rcvbuf_errors_per_sec\x18\x15 \x01(\x01R\x15udpRcvbufErrorsPerSec\x128\n" +
+	"\x19udp_sndbuf_errors_per_sec\x18\x16 \x01(\x01R\x15udpSndbufErrorsPerSec\x1a<\n" +
+	"\x0eTcpStatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"y\n" +
+	"\x0fListenerMetrics\x124\n" +
+	"\asockets\x18\x01 \x03(\v2\x1a.collector.ListeningSocketR\asockets\x120\n" +
+	"\x06events\x18\x02 \x03(\v2\x18.collector.ListenerEventR\x06events\"\x99\x01\n" +
+	"\x0fListeningSocket\x12\x1a\n" +
+	"\bprotocol\x18\x01 \x01(\tR\bprotocol\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x10\n" +
+	"\x03pid\x18\x04 \x01(\x05R\x03pid\x12\x18\n" +
+	"\aprocess\x18\x05 \x01(\tR\aprocess\x12\x10\n" +
+	"\x03uid\x18\x06 \x01(\x05R\x03uid\"u\n" +
+	"\rListenerEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x122\n" +
+	"\x06socket\x18\x02 \x01(\v2\x1a.collector.ListeningSocketR\x06socket\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"\x86\x03\n" +
+	"\rLimitsMetrics\x12/\n" +
+	"\x13conntrack_supported\x18\x01 \x01(\bR\x12conntrackSupported\x12'\n" +
+	"\x0fconntrack_count\x18\x02 \x01(\x04R\x0econntrackCount\x12#\n" +
+	"\rconntrack_max\x18\x03 \x01(\x04R\fconntrackMax\x12+\n" +
+	"\x11conntrack_percent\x18\x04 \x01(\x01R\x10conntrackPercent\x124\n" +
+	"\x16file_handles_allocated\x18\x05 \x01(\x04R\x14fileHandlesAllocated\x12(\n" +
+	"\x10file_handles_max\x18\x06 \x01(\x04R\x0efileHandlesMax\x120\n" +
+	"\x14file_handles_percent\x18\a \x01(\x01R\x12fileHandlesPercent\x127\n" +
+	"\tprocesses\x18\b \x03(\v2\x19.collector.ProcessFDUsageR\tprocesses\"\xa9\x01\n" +
+	"\x0eProcessFDUsage\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bopen_fds\x18\x03 \x01(\x04R\aopenFds\x12\x1d\n" +
+	"\n" +
+	"soft_limit\x18\x04 \x01(\x04R\tsoftLimit\x12\x1d\n" +
+	"\n" +
+	"hard_limit\x18\x05 \x01(\x04R\thardLimit\x12\x18\n" +
+	"\apercent\x18\x06 \x01(\x01R\apercent\"\xd8\x02\n" +
+	"\x13ProcessStateMetrics\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12B\n" +
+	"\x06states\x18\x02 \x03(\v2*.collector.ProcessStateMetrics.StatesEntryR\x06states\x122\n" +
+	"\azombies\x18\x03 \x03(\v2\x18.collector.ZombieProcessR\azombies\x12;\n" +
+	"\rstuck_d_state\x18\x04 \x03(\v2\x17.collector.StuckProcessR\vstuckDState\x12;\n" +
+	"\rleak_suspects\x18\x05 \x03(\v2\x16.collector.LeakSuspectR\fleakSuspects\x1a9\n" +
+	"\vStatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"j\n" +
+	"\rZombieProcess\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04ppid\x18\x03 \x01(\x05R\x04ppid\x12\x1f\n" +
+	"\vparent_name\x18\x04 \x01(\tR\n" +
+	"parentName\"N\n" +
+	"\fStuckProcess\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aseconds\x18\x03 \x01(\x03R\aseconds\"\x8d\x01\n" +
+	"\vLeakSuspect\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\trss_bytes\x18\x03 \x01(\x04R\brssBytes\x12!\n" +
+	"\fgrowth_bytes\x18\x04 \x01(\x04R\vgrowthBytes\x12\x18\n" +
+	"\asamples\x18\x05 \x01(\x05R\asamples\"E\n" +
 	"\x0fMetricsResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"I\n" +
@@ -1704,11 +4244,20 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\x11HeartbeatResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
 	"\vserver_time\x18\x02 \x01(\x03R\n" +
-	"serverTime\"\x83\x01\n" +
+	"serverTime\"\xb4\x01\n" +
 	"\x14ProcessReportRequest\x12\x17\n" +
 	"\ahost_id\x18\x01 \x01(\tR\x06hostId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x124\n" +
-	"\tprocesses\x18\x03 \x03(\v2\x16.collector.ProcessInfoR\tprocesses\"\x85\x02\n" +
+	"\tprocesses\x18\x03 \x03(\v2\x16.collector.ProcessInfoR\tprocesses\x12/\n" +
+	"\x06groups\x18\x04 \x03(\v2\x17.collector.ProcessGroupR\x06groups\"\xa3\x01\n" +
+	"\fProcessGroup\x12\x0e\n" +
+	"\x02by\x18\x01 \x01(\tR\x02by\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vcpu_percent\x18\x04 \x01(\x01R\n" +
+	"cpuPercent\x12!\n" +
+	"\fmemory_bytes\x18\x05 \x01(\x04R\vmemoryBytes\x12\x17\n" +
+	"\anum_fds\x18\x06 \x01(\x03R\x06numFds\"\xa5\x05\n" +
 	"\vProcessInfo\x12\x10\n" +
 	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -1720,7 +4269,22 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\vcreate_time\x18\a \x01(\x03R\n" +
 	"createTime\x12\x16\n" +
 	"\x06status\x18\b \x01(\tR\x06status\x12\x18\n" +
-	"\acommand\x18\t \x01(\tR\acommand\"r\n" +
+	"\acommand\x18\t \x01(\tR\acommand\x12\x18\n" +
+	"\awatched\x18\n" +
+	" \x01(\bR\awatched\x12\x12\n" +
+	"\x04ppid\x18\v \x01(\x05R\x04ppid\x12\x1f\n" +
+	"\vnum_threads\x18\f \x01(\x05R\n" +
+	"numThreads\x12\x17\n" +
+	"\anum_fds\x18\r \x01(\x05R\x06numFds\x12+\n" +
+	"\x12read_bytes_per_sec\x18\x0e \x01(\x01R\x0freadBytesPerSec\x12-\n" +
+	"\x13write_bytes_per_sec\x18\x0f \x01(\x01R\x10writeBytesPerSec\x124\n" +
+	"\x16voluntary_ctx_switches\x18\x10 \x01(\x03R\x14voluntaryCtxSwitches\x128\n" +
+	"\x18involuntary_ctx_switches\x18\x11 \x01(\x03R\x16involuntaryCtxSwitches\x12\x12\n" +
+	"\x04nice\x18\x12 \x01(\x05R\x04nice\x12\x1f\n" +
+	"\vcgroup_path\x18\x13 \x01(\tR\n" +
+	"cgroupPath\x12!\n" +
+	"\fcontainer_id\x18\x14 \x01(\tR\vcontainerId\x12\x12\n" +
+	"\x04unit\x18\x15 \x01(\tR\x04unit\"r\n" +
 	"\x10LogReportRequest\x12\x17\n" +
 	"\ahost_id\x18\x01 \x01(\tR\x06hostId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12'\n" +
@@ -1749,7 +4313,7 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\x14ServiceStatusRequest\x12\x17\n" +
 	"\ahost_id\x18\x01 \x01(\tR\x06hostId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x122\n" +
-	"\bservices\x18\x03 \x03(\v2\x16.collector.ServiceInfoR\bservices\"\xd9\x01\n" +
+	"\bservices\x18\x03 \x03(\v2\x16.collector.ServiceInfoR\bservices\"\xa3\x02\n" +
 	"\vServiceInfo\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
@@ -1757,7 +4321,11 @@ const file_proto_collector_proto_rawDesc = "" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12%\n" +
 	"\x0euptime_seconds\x18\x05 \x01(\x03R\ruptimeSeconds\x12\x12\n" +
 	"\x04port\x18\x06 \x01(\x05R\x04port\x12'\n" +
-	"\x0fport_accessible\x18\a \x01(\bR\x0eportAccessible2\xf5\x04\n" +
+	"\x0fport_accessible\x18\a \x01(\bR\x0eportAccessible\x12\x16\n" +
+	"\x06source\x18\b \x01(\tR\x06source\x12\x1c\n" +
+	"\tinstances\x18\t \x01(\x05R\tinstances\x12\x12\n" +
+	"\x04pids\x18\n" +
+	" \x03(\x05R\x04pids2\xf5\x04\n" +
 	"\tCollector\x12H\n" +
 	"\rRegisterAgent\x12\x1a.collector.RegisterRequest\x1a\x1b.collector.RegisterResponse\x12F\n" +
 	"\rReportMetrics\x12\x19.collector.MetricsRequest\x1a\x1a.collector.MetricsResponse\x12F\n" +
@@ -1781,7 +4349,7 @@ func file_proto_collector_proto_rawDescGZIP() []byte {
 	return file_proto_collector_proto_rawDescData
 }
 
-var file_proto_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_proto_collector_proto_goTypes = []any{
 	(*RegisterRequest)(nil),      // 0: collector.RegisterRequest
 	(*RegisterResponse)(nil),     // 1: collector.RegisterResponse
@@ -1790,58 +4358,102 @@ var file_proto_collector_proto_goTypes = []any{
 	(*MemoryMetrics)(nil),        // 4: collector.MemoryMetrics
 	(*DiskMetrics)(nil),          // 5: collector.DiskMetrics
 	(*PartitionMetrics)(nil),     // 6: collector.PartitionMetrics
-	(*NetworkMetrics)(nil),       // 7: collector.NetworkMetrics
-	(*InterfaceMetrics)(nil),     // 8: collector.InterfaceMetrics
-	(*GPUMetrics)(nil),           // 9: collector.GPUMetrics
-	(*GPUDeviceMetrics)(nil),     // 10: collector.GPUDeviceMetrics
-	(*MetricsResponse)(nil),      // 11: collector.MetricsResponse
-	(*HeartbeatRequest)(nil),     // 12: collector.HeartbeatRequest
-	(*HeartbeatResponse)(nil),    // 13: collector.HeartbeatResponse
-	(*ProcessReportRequest)(nil), // 14: collector.ProcessReportRequest
-	(*ProcessInfo)(nil),          // 15: collector.ProcessInfo
-	(*LogReportRequest)(nil),     // 16: collector.LogReportRequest
-	(*LogEntry)(nil),             // 17: collector.LogEntry
-	(*ScriptResultRequest)(nil),  // 18: collector.ScriptResultRequest
-	(*ServiceStatusRequest)(nil), // 19: collector.ServiceStatusRequest
-	(*ServiceInfo)(nil),          // 20: collector.ServiceInfo
-	nil,                          // 21: collector.RegisterRequest.TagsEntry
-	nil,                          // 22: collector.LogEntry.TagsEntry
+	(*DiskIOMetrics)(nil),        // 7: collector.DiskIOMetrics
+	(*NetworkMetrics)(nil),       // 8: collector.NetworkMetrics
+	(*InterfaceMetrics)(nil),     // 9: collector.InterfaceMetrics
+	(*GPUMetrics)(nil),           // 10: collector.GPUMetrics
+	(*GPUDeviceMetrics)(nil),     // 11: collector.GPUDeviceMetrics
+	(*CgroupMetrics)(nil),        // 12: collector.CgroupMetrics
+	(*CgroupInfo)(nil),           // 13: collector.CgroupInfo
+	(*PressureMetrics)(nil),      // 14: collector.PressureMetrics
+	(*PressureResource)(nil),     // 15: collector.PressureResource
+	(*PressureStat)(nil),         // 16: collector.PressureStat
+	(*KernelMetrics)(nil),        // 17: collector.KernelMetrics
+	(*NetstackMetrics)(nil),      // 18: collector.NetstackMetrics
+	(*ListenerMetrics)(nil),      // 19: collector.ListenerMetrics
+	(*ListeningSocket)(nil),      // 20: collector.ListeningSocket
+	(*ListenerEvent)(nil),        // 21: collector.ListenerEvent
+	(*LimitsMetrics)(nil),        // 22: collector.LimitsMetrics
+	(*ProcessFDUsage)(nil),       // 23: collector.ProcessFDUsage
+	(*ProcessStateMetrics)(nil),  // 24: collector.ProcessStateMetrics
+	(*ZombieProcess)(nil),        // 25: collector.ZombieProcess
+	(*StuckProcess)(nil),         // 26: collector.StuckProcess
+	(*LeakSuspect)(nil),          // 27: collector.LeakSuspect
+	(*MetricsResponse)(nil),      // 28: collector.MetricsResponse
+	(*HeartbeatRequest)(nil),     // 29: collector.HeartbeatRequest
+	(*HeartbeatResponse)(nil),    // 30: collector.HeartbeatResponse
+	(*ProcessReportRequest)(nil), // 31: collector.ProcessReportRequest
+	(*ProcessGroup)(nil),         // 32: collector.ProcessGroup
+	(*ProcessInfo)(nil),          // 33: collector.ProcessInfo
+	(*LogReportRequest)(nil),     // 34: collector.LogReportRequest
+	(*LogEntry)(nil),             // 35: collector.LogEntry
+	(*ScriptResultRequest)(nil),  // 36: collector.ScriptResultRequest
+	(*ServiceStatusRequest)(nil), // 37: collector.ServiceStatusRequest
+	(*ServiceInfo)(nil),          // 38: collector.ServiceInfo
+	nil,                          // 39: collector.RegisterRequest.TagsEntry
+	nil,                          // 40: collector.NetstackMetrics.TcpStatesEntry
+	nil,                          // 41: collector.ProcessStateMetrics.StatesEntry
+	nil,                          // 42: collector.LogEntry.TagsEntry
 }
 var file_proto_collector_proto_depIdxs = []int32{
-	21, // 0: collector.RegisterRequest.tags:type_name -> collector.RegisterRequest.TagsEntry
+	39, // 0: collector.RegisterRequest.tags:type_name -> collector.RegisterRequest.TagsEntry
 	3,  // 1: collector.MetricsRequest.cpu:type_name -> collector.CPUMetrics
 	4,  // 2: collector.MetricsRequest.memory:type_name -> collector.MemoryMetrics
 	5,  // 3: collector.MetricsRequest.disk:type_name -> collector.DiskMetrics
-	7,  // 4: collector.MetricsRequest.network:type_name -> collector.NetworkMetrics
-	9,  // 5: collector.MetricsRequest.gpu:type_name -> collector.GPUMetrics
-	6,  // 6: collector.DiskMetrics.partitions:type_name -> collector.PartitionMetrics
-	8,  // 7: collector.NetworkMetrics.interfaces:type_name -> collector.InterfaceMetrics
-	10, // 8: collector.GPUMetrics.devices:type_name -> collector.GPUDeviceMetrics
-	15, // 9: collector.ProcessReportRequest.processes:type_name -> collector.ProcessInfo
-	17, // 10: collector.LogReportRequest.logs:type_name -> collector.LogEntry
-	22, // 11: collector.LogEntry.tags:type_name -> collector.LogEntry.TagsEntry
-	20, // 12: collector.ServiceStatusRequest.services:type_name -> collector.ServiceInfo
-	0,  // 13: collector.Collector.RegisterAgent:input_type -> collector.RegisterRequest
-	2,  // 14: collector.Collector.ReportMetrics:input_type -> collector.MetricsRequest
-	12, // 15: collector.Collector.Heartbeat:input_type -> collector.HeartbeatRequest
-	14, // 16: collector.Collector.ReportProcesses:input_type -> collector.ProcessReportRequest
-	16, // 17: collector.Collector.ReportLogs:input_type -> collector.LogReportRequest
-	18, // 18: collector.Collector.ReportScriptResult:input_type -> collector.ScriptResultRequest
-	19, // 19: collector.Collector.ReportServiceStatus:input_type -> collector.ServiceStatusRequest
-	16, // 20: collector.Collector.ReportDockerContainers:input_type -> collector.LogReportRequest
-	1,  // 21: collector.Collector.RegisterAgent:output_type -> collector.RegisterResponse
-	11, // 22: collector.Collector.ReportMetrics:output_type -> collector.MetricsResponse
-	13, // 23: collector.Collector.Heartbeat:output_type -> collector.HeartbeatResponse
-	11, // 24: collector.Collector.ReportProcesses:output_type -> collector.MetricsResponse
-	11, // 25: collector.Collector.ReportLogs:output_type -> collector.MetricsResponse
-	11, // 26: collector.Collector.ReportScriptResult:output_type -> collector.MetricsResponse
-	11, // 27: collector.Collector.ReportServiceStatus:output_type -> collector.MetricsResponse
-	11, // 28: collector.Collector.ReportDockerContainers:output_type -> collector.MetricsResponse
-	21, // [21:29] is the sub-list for method output_type
-	13, // [13:21] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	8,  // 4: collector.MetricsRequest.network:type_name -> collector.NetworkMetrics
+	10, // 5: collector.MetricsRequest.gpu:type_name -> collector.GPUMetrics
+	12, // 6: collector.MetricsRequest.cgroup:type_name -> collector.CgroupMetrics
+	14, // 7: collector.MetricsRequest.pressure:type_name -> collector.PressureMetrics
+	17, // 8: collector.MetricsRequest.kernel:type_name -> collector.KernelMetrics
+	18, // 9: collector.MetricsRequest.netstack:type_name -> collector.NetstackMetrics
+	19, // 10: collector.MetricsRequest.listeners:type_name -> collector.ListenerMetrics
+	22, // 11: collector.MetricsRequest.limits:type_name -> collector.LimitsMetrics
+	24, // 12: collector.MetricsRequest.process_states:type_name -> collector.ProcessStateMetrics
+	6,  // 13: collector.DiskMetrics.partitions:type_name -> collector.PartitionMetrics
+	7,  // 14: collector.DiskMetrics.io:type_name -> collector.DiskIOMetrics
+	9,  // 15: collector.NetworkMetrics.interfaces:type_name -> collector.InterfaceMetrics
+	11, // 16: collector.GPUMetrics.devices:type_name -> collector.GPUDeviceMetrics
+	13, // 17: collector.CgroupMetrics.groups:type_name -> collector.CgroupInfo
+	15, // 18: collector.PressureMetrics.cpu:type_name -> collector.PressureResource
+	15, // 19: collector.PressureMetrics.memory:type_name -> collector.PressureResource
+	15, // 20: collector.PressureMetrics.io:type_name -> collector.PressureResource
+	16, // 21: collector.PressureResource.some:type_name -> collector.PressureStat
+	16, // 22: collector.PressureResource.full:type_name -> collector.PressureStat
+	40, // 23: collector.NetstackMetrics.tcp_states:type_name -> collector.NetstackMetrics.TcpStatesEntry
+	20, // 24: collector.ListenerMetrics.sockets:type_name -> collector.ListeningSocket
+	21, // 25: collector.ListenerMetrics.events:type_name -> collector.ListenerEvent
+	20, // 26: collector.ListenerEvent.socket:type_name -> collector.ListeningSocket
+	23, // 27: collector.LimitsMetrics.processes:type_name -> collector.ProcessFDUsage
+	41, // 28: collector.ProcessStateMetrics.states:type_name -> collector.ProcessStateMetrics.StatesEntry
+	25, // 29: collector.ProcessStateMetrics.zombies:type_name -> collector.ZombieProcess
+	26, // 30: collector.ProcessStateMetrics.stuck_d_state:type_name -> collector.StuckProcess
+	27, // 31: collector.ProcessStateMetrics.leak_suspects:type_name -> collector.LeakSuspect
+	33, // 32: collector.ProcessReportRequest.processes:type_name -> collector.ProcessInfo
+	32, // 33: collector.ProcessReportRequest.groups:type_name -> collector.ProcessGroup
+	35, // 34: collector.LogReportRequest.logs:type_name -> collector.LogEntry
+	42, // 35: collector.LogEntry.tags:type_name -> collector.LogEntry.TagsEntry
+	38, // 36: collector.ServiceStatusRequest.services:type_name -> collector.ServiceInfo
+	0,  // 37: collector.Collector.RegisterAgent:input_type -> collector.RegisterRequest
+	2,  // 38: collector.Collector.ReportMetrics:input_type -> collector.MetricsRequest
+	29, // 39: collector.Collector.Heartbeat:input_type -> collector.HeartbeatRequest
+	31, // 40: collector.Collector.ReportProcesses:input_type -> collector.ProcessReportRequest
+	34, // 41: collector.Collector.ReportLogs:input_type -> collector.LogReportRequest
+	36, // 42: collector.Collector.ReportScriptResult:input_type -> collector.ScriptResultRequest
+	37, // 43: collector.Collector.ReportServiceStatus:input_type -> collector.ServiceStatusRequest
+	34, // 44: collector.Collector.ReportDockerContainers:input_type -> collector.LogReportRequest
+	1,  // 45: collector.Collector.RegisterAgent:output_type -> collector.RegisterResponse
+	28, // 46: collector.Collector.ReportMetrics:output_type -> collector.MetricsResponse
+	30, // 47: collector.Collector.Heartbeat:output_type -> collector.HeartbeatResponse
+	28, // 48: collector.Collector.ReportProcesses:output_type -> collector.MetricsResponse
+	28, // 49: collector.Collector.ReportLogs:output_type -> collector.MetricsResponse
+	28, // 50: collector.Collector.ReportScriptResult:output_type -> collector.MetricsResponse
+	28, // 51: collector.Collector.ReportServiceStatus:output_type -> collector.MetricsResponse
+	28, // 52: collector.Collector.ReportDockerContainers:output_type -> collector.MetricsResponse
+	45, // [45:53] is the sub-list for method output_type
+	37, // [37:45] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_proto_collector_proto_init() }
@@ -1855,7 +4467,7 @@ func file_proto_collector_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_collector_proto_rawDesc), len(file_proto_collector_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
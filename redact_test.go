@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+func TestRedactorBuiltinPatterns(t *testing.T) {
+	redactor := NewRedactor(RedactionConfig{Enabled: true})
+
+	tests := map[string]string{
+		"app --password=hunter2 --port 8080":                  "app --password=*** --port 8080",
+		"app --api-key abc123 -v":                             "app --api-key *** -v",
+		`app --db-password "with space" --user root`:          `app --db-password "***" --user root`,
+		"mysql -u root -psecret db":                           "mysql -u root -p*** db",
+		"mysql -u root -p db":                                 "mysql -u root -p db",
+		"/usr/bin/mysqldump -h db1 -pTopSecret shop":          "/usr/bin/mysqldump -h db1 -p*** shop",
+		"mkdir -p /var/run/app":                               "mkdir -p /var/run/app",
+		"cp -p a b":                                           "cp -p a b",
+		"nginx -p /usr/local/nginx":                           "nginx -p /usr/local/nginx",
+		"./server -port=8080":                                 "./server -port=8080",
+		"app -pidfile /run/a.pid":                             "app -pidfile /run/a.pid",
+		"docker run -p 8080:80 nginx":                         "docker run -p 8080:80 nginx",
+		"ssh -p 22 host":                                      "ssh -p 22 host",
+		"sshpass -p secret ssh root@host":                     "sshpass -p *** ssh root@host",
+		"/usr/bin/sshpass -psecret rsync -a src host:dst":     "/usr/bin/sshpass -p*** rsync -a src host:dst",
+		"sshpass -f /etc/pw ssh -p 22 host":                   "sshpass -f /etc/pw ssh -p 22 host",
+		"mongodump -u admin -p s3cret --db shop":              "mongodump -u admin -p *** --db shop",
+		"worker postgres://app:s3cr3t@db:5432/prod":           "worker postgres://app:***@db:5432/prod",
+		"env DB_PASSWORD=hunter2 AWS_SECRET_ACCESS_KEY=abc x": "env DB_PASSWORD=*** AWS_SECRET_ACCESS_KEY=*** x",
+		"GET /api Authorization: Bearer eyJhbGciOi.x.y":       "GET /api Authorization: Bearer ***",
+		"nginx: worker process":                               "nginx: worker process",
+		"app --no-password --verbose":                         "app --no-password --verbose",
+	}
+	for input, want := range tests {
+		if got := redactor.Redact(input); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestRedactorCustomPatternsAndDisabled(t *testing.T) {
+	redactor := NewRedactor(RedactionConfig{
+		Enabled:  true,
+		Mask:     "[REDACTED]",
+		Patterns: []string{`card=(\d{12,19})`, `\b\d{3}-\d{2}-\d{4}\b`, `(`},
+	})
+
+	got := redactor.Redact("charge card=4111111111111111 ssn 123-45-6789")
+	want := "charge card=[REDACTED] ssn [REDACTED]"
+	if got != want {
+		t.Fatalf("Redact() = %q, want %q", got, want)
+	}
+
+	var disabled *Redactor = NewRedactor(RedactionConfig{Enabled: false})
+	if input := "--password=hunter2"; disabled.Redact(input) != input {
+		t.Fatal("expected disabled redactor to leave text unchanged")
+	}
+}
+
+func TestScriptExecutorRedactsOutput(t *testing.T) {
+	executor := NewScriptExecutor(nil, NewRedactor(RedactionConfig{Enabled: true}))
+	result := executor.executeScript(ScriptConfig{ID: "env", Command: "echo DB_PASSWORD=hunter2; echo token=abc >&2"})
+	if result.Output != "DB_PASSWORD=***\n" {
+		t.Fatalf("unexpected redacted stdout: %q", result.Output)
+	}
+	if result.Error != "token=***\n" {
+		t.Fatalf("unexpected redacted stderr: %q", result.Error)
+	}
+}
@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -182,22 +183,51 @@ func (r *Reporter) metricsRequest(data *MetricsData) *pb.MetricsRequest {
 	// CPU指标
 	if cpu, ok := data.Metrics["cpu"].(*CPUMetrics); ok {
 		req.Cpu = &pb.CPUMetrics{
-			UsagePercent: cpu.UsagePercent,
-			LoadAvg_1:    cpu.LoadAvg1,
-			LoadAvg_5:    cpu.LoadAvg5,
-			LoadAvg_15:   cpu.LoadAvg15,
-			CoreCount:    int32(cpu.CoreCount),
+			UsagePercent:   cpu.UsagePercent,
+			LoadAvg_1:      cpu.LoadAvg1,
+			LoadAvg_5:      cpu.LoadAvg5,
+			LoadAvg_15:     cpu.LoadAvg15,
+			CoreCount:      int32(cpu.CoreCount),
+			PerCorePercent: cpu.PerCorePercent,
+			UserPercent:    cpu.UserPercent,
+			SystemPercent:  cpu.SystemPercent,
+			NicePercent:    cpu.NicePercent,
+			IowaitPercent:  cpu.IowaitPercent,
+			IrqPercent:     cpu.IrqPercent,
+			SoftirqPercent: cpu.SoftirqPercent,
+			StealPercent:   cpu.StealPercent,
+			GuestPercent:   cpu.GuestPercent,
 		}
 	}
 
 	// 内存指标
 	if mem, ok := data.Metrics["memory"].(*MemoryMetrics); ok {
 		req.Memory = &pb.MemoryMetrics{
-			Total:       mem.Total,
-			Used:        mem.Used,
-			Free:        mem.Free,
-			UsedPercent: mem.UsedPercent,
-			Available:   mem.Available,
+			Total:                mem.Total,
+			Used:                 mem.Used,
+			Free:                 mem.Free,
+			UsedPercent:          mem.UsedPercent,
+			Available:            mem.Available,
+			SwapTotal:            mem.SwapTotal,
+			SwapUsed:             mem.SwapUsed,
+			SwapFree:             mem.SwapFree,
+			SwapUsedPercent:      mem.SwapUsedPercent,
+			Buffers:              mem.Buffers,
+			Cached:               mem.Cached,
+			Shared:               mem.Shared,
+			SlabReclaimable:      mem.SlabReclaimable,
+			SlabUnreclaimable:    mem.SlabUnreclaimable,
+			Dirty:                mem.Dirty,
+			Writeback:            mem.Writeback,
+			CommittedAs:          mem.CommittedAS,
+			CommitLimit:          mem.CommitLimit,
+			CommitPercent:        mem.CommitPercent,
+			HugePagesTotal:       mem.HugePagesTotal,
+			HugePagesFree:        mem.HugePagesFree,
+			HugePagesReserved:    mem.HugePagesReserved,
+			HugePageSize:         mem.HugePageSize,
+			HugePagesUsedPercent: mem.HugePagesUsedPercent,
+			AnonHugePages:        mem.AnonHugePages,
 		}
 	}
 
@@ -208,13 +238,36 @@ func (r *Reporter) metricsRequest(data *MetricsData) *pb.MetricsRequest {
 		}
 		for _, p := range disk.Partitions {
 			diskMetrics.Partitions = append(diskMetrics.Partitions, &pb.PartitionMetrics{
-				Device:      p.Device,
-				Mountpoint:  p.Mountpoint,
-				Fstype:      p.Fstype,
-				Total:       p.Total,
-				Used:        p.Used,
-				Free:        p.Free,
-				UsedPercent: p.UsedPercent,
+				Device:               p.Device,
+				Mountpoint:           p.Mountpoint,
+				Fstype:               p.Fstype,
+				Total:                p.Total,
+				Used:                 p.Used,
+				Free:                 p.Free,
+				UsedPercent:          p.UsedPercent,
+				InodesTotal:          p.InodesTotal,
+				InodesUsed:           p.InodesUsed,
+				InodesFree:           p.InodesFree,
+				InodesUsedPercent:    p.InodesUsedPercent,
+				GrowthBytesPerSecond: p.GrowthBytesPerSecond,
+				SecondsUntilFull:     p.SecondsUntilFull,
+			})
+		}
+		for _, io := range disk.IO {
+			diskMetrics.Io = append(diskMetrics.Io, &pb.DiskIOMetrics{
+				Device:           io.Device,
+				Label:            io.Label,
+				Mountpoints:      io.Mountpoints,
+				ReadBytesPerSec:  io.ReadBytesPerSec,
+				WriteBytesPerSec: io.WriteBytesPerSec,
+				ReadIops:         io.ReadIOPS,
+				WriteIops:        io.WriteIOPS,
+				ReadAwaitMs:      io.ReadAwaitMs,
+				WriteAwaitMs:     io.WriteAwaitMs,
+				AwaitMs:          io.AwaitMs,
+				QueueDepth:       io.QueueDepth,
+				UtilPercent:      io.UtilPercent,
+				IopsInProgress:   io.IopsInProgress,
 			})
 		}
 		req.Disk = diskMetrics
@@ -234,6 +287,25 @@ func (r *Reporter) metricsRequest(data *MetricsData) *pb.MetricsRequest {
 				PacketsRecv: iface.PacketsRecv,
 				Errin:       iface.Errin,
 				Errout:      iface.Errout,
+				Dropin:      iface.Dropin,
+				Dropout:     iface.Dropout,
+
+				BytesSentPerSec:   iface.BytesSentPerSec,
+				BytesRecvPerSec:   iface.BytesRecvPerSec,
+				PacketsSentPerSec: iface.PacketsSentPerSec,
+				PacketsRecvPerSec: iface.PacketsRecvPerSec,
+				ErrinPerSec:       iface.ErrinPerSec,
+				ErroutPerSec:      iface.ErroutPerSec,
+				DropinPerSec:      iface.DropinPerSec,
+				DropoutPerSec:     iface.DropoutPerSec,
+
+				OperState:          iface.OperState,
+				SpeedMbps:          iface.SpeedMbps,
+				Duplex:             iface.Duplex,
+				Mtu:                int32(iface.MTU),
+				HardwareAddr:       iface.HardwareAddr,
+				Addresses:          iface.Addresses,
+				UtilizationPercent: iface.UtilizationPercent,
 			})
 		}
 		req.Network = netMetrics
@@ -263,9 +335,186 @@ func (r *Reporter) metricsRequest(data *MetricsData) *pb.MetricsRequest {
 		req.Gpu = gpuMetrics
 	}
 
+	if cgroup, ok := data.Metrics["cgroup"].(*CgroupMetrics); ok {
+		cgroupMetrics := &pb.CgroupMetrics{
+			Supported: cgroup.Supported,
+			Groups:    make([]*pb.CgroupInfo, 0, len(cgroup.Groups)),
+		}
+		for _, group := range cgroup.Groups {
+			cgroupMetrics.Groups = append(cgroupMetrics.Groups, &pb.CgroupInfo{
+				Path:                    group.Path,
+				Kind:                    group.Kind,
+				Name:                    group.Name,
+				ContainerId:             group.ContainerID,
+				Unit:                    group.Unit,
+				CpuUsageUsec:            group.CPUUsageUsec,
+				CpuThrottledUsec:        group.CPUThrottledUsec,
+				CpuPercent:              group.CPUPercent,
+				MemoryCurrent:           group.MemoryCurrent,
+				MemoryMax:               group.MemoryMax,
+				MemoryPercent:           group.MemoryPercent,
+				MemoryPressureSomeAvg10: group.MemoryPressureSomeAvg10,
+				MemoryPressureFullAvg10: group.MemoryPressureFullAvg10,
+				IoReadBytes:             group.IOReadBytes,
+				IoWriteBytes:            group.IOWriteBytes,
+				IoReadBytesPerSec:       group.IOReadBytesPerSec,
+				IoWriteBytesPerSec:      group.IOWriteBytesPerSec,
+				PidsCurrent:             group.PidsCurrent,
+				CpuPressureSomeAvg10:    group.CPUPressureSomeAvg10,
+				IoPressureSomeAvg10:     group.IOPressureSomeAvg10,
+				IoPressureFullAvg10:     group.IOPressureFullAvg10,
+			})
+		}
+		req.Cgroup = cgroupMetrics
+	}
+
+	if pressure, ok := data.Metrics["pressure"].(*PressureMetrics); ok {
+		req.Pressure = &pb.PressureMetrics{
+			Supported: pressure.Supported,
+			Cpu:       pressureResourceProto(pressure.CPU),
+			Memory:    pressureResourceProto(pressure.Memory),
+			Io:        pressureResourceProto(pressure.IO),
+		}
+	}
+
+	if kernel, ok := data.Metrics["kernel"].(*KernelMetrics); ok {
+		req.Kernel = &pb.KernelMetrics{
+			ContextSwitchesPerSec: kernel.ContextSwitchesPerSec,
+			InterruptsPerSec:      kernel.InterruptsPerSec,
+			ForksPerSec:           kernel.ForksPerSec,
+			ProcsRunning:          kernel.ProcsRunning,
+			ProcsBlocked:          kernel.ProcsBlocked,
+			PageFaultsPerSec:      kernel.PageFaultsPerSec,
+			MajorPageFaultsPerSec: kernel.MajorPageFaultsPerSec,
+			SwapInPagesPerSec:     kernel.SwapInPagesPerSec,
+			SwapOutPagesPerSec:    kernel.SwapOutPagesPerSec,
+			PageScanKswapdPerSec:  kernel.PageScanKswapdPerSec,
+			PageScanDirectPerSec:  kernel.PageScanDirectPerSec,
+			PageStealPerSec:       kernel.PageStealPerSec,
+			OomKills:              kernel.OOMKills,
+			OomKillsDelta:         kernel.OOMKillsDelta,
+		}
+	}
+
+	if netstack, ok := data.Metrics["netstack"].(*NetstackMetrics); ok {
+		req.Netstack = &pb.NetstackMetrics{
+			TcpStates:              netstack.TCPStates,
+			TcpSockets:             netstack.TCPSockets,
+			UdpSockets:             netstack.UDPSockets,
+			TcpCurrEstab:           netstack.TCPCurrEstab,
+			TcpActiveOpensPerSec:   netstack.TCPActiveOpensPerSec,
+			TcpPassiveOpensPerSec:  netstack.TCPPassiveOpensPerSec,
+			TcpAttemptFailsPerSec:  netstack.TCPAttemptFailsPerSec,
+			TcpEstabResetsPerSec:   netstack.TCPEstabResetsPerSec,
+			TcpInErrsPerSec:        netstack.TCPInErrsPerSec,
+			TcpOutRstsPerSec:       netstack.TCPOutRstsPerSec,
+			TcpOutSegsPerSec:       netstack.TCPOutSegsPerSec,
+			TcpRetransSegsPerSec:   netstack.TCPRetransSegsPerSec,
+			TcpRetransPercent:      netstack.TCPRetransPercent,
+			ListenOverflowsPerSec:  netstack.ListenOverflowsPerSec,
+			ListenDropsPerSec:      netstack.ListenDropsPerSec,
+			SyncookiesSentPerSec:   netstack.SyncookiesSentPerSec,
+			SyncookiesRecvPerSec:   netstack.SyncookiesRecvPerSec,
+			SyncookiesFailedPerSec: netstack.SyncookiesFailedPerSec,
+			UdpInErrorsPerSec:      netstack.UDPInErrorsPerSec,
+			UdpNoPortsPerSec:       netstack.UDPNoPortsPerSec,
+			UdpRcvbufErrorsPerSec:  netstack.UDPRcvbufErrorsPerSec,
+			UdpSndbufErrorsPerSec:  netstack.UDPSndbufErrorsPerSec,
+		}
+	}
+
+	if listeners, ok := data.Metrics["listener"].(*ListenerMetrics); ok {
+		listenerMetrics := &pb.ListenerMetrics{
+			Sockets: make([]*pb.ListeningSocket, 0, len(listeners.Sockets)),
+			Events:  make([]*pb.ListenerEvent, 0, len(listeners.Events)),
+		}
+		for _, socket := range listeners.Sockets {
+			listenerMetrics.Sockets = append(listenerMetrics.Sockets, listeningSocketProto(socket))
+		}
+		for _, event := range listeners.Events {
+			listenerMetrics.Events = append(listenerMetrics.Events, &pb.ListenerEvent{
+				Type:      event.Type,
+				Socket:    listeningSocketProto(event.Socket),
+				Timestamp: event.Timestamp,
+			})
+		}
+		req.Listeners = listenerMetrics
+	}
+
+	if limits, ok := data.Metrics["limits"].(*LimitsMetrics); ok {
+		limitsMetrics := &pb.LimitsMetrics{
+			ConntrackSupported:   limits.ConntrackSupported,
+			ConntrackCount:       limits.ConntrackCount,
+			ConntrackMax:         limits.ConntrackMax,
+			ConntrackPercent:     limits.ConntrackPercent,
+			FileHandlesAllocated: limits.FileHandlesAllocated,
+			FileHandlesMax:       limits.FileHandlesMax,
+			FileHandlesPercent:   limits.FileHandlesPercent,
+			Processes:            make([]*pb.ProcessFDUsage, 0, len(limits.Processes)),
+		}
+		for _, usage := range limits.Processes {
+			limitsMetrics.Processes = append(limitsMetrics.Processes, &pb.ProcessFDUsage{
+				Pid:       usage.PID,
+				Name:      usage.Name,
+				OpenFds:   usage.OpenFDs,
+				SoftLimit: usage.SoftLimit,
+				HardLimit: usage.HardLimit,
+				Percent:   usage.Percent,
+			})
+		}
+		req.Limits = limitsMetrics
+	}
+
+	if states, ok := data.Metrics["process_state"].(*ProcessStateMetrics); ok {
+		stateMetrics := &pb.ProcessStateMetrics{
+			Total:        int32(states.Total),
+			States:       make(map[string]int32, len(states.States)),
+			Zombies:      make([]*pb.ZombieProcess, 0, len(states.Zombies)),
+			StuckDState:  make([]*pb.StuckProcess, 0, len(states.StuckDState)),
+			LeakSuspects: make([]*pb.LeakSuspect, 0, len(states.LeakSuspects)),
+		}
+		for state, count := range states.States {
+			stateMetrics.States[state] = int32(count)
+		}
+		for _, z := range states.Zombies {
+			stateMetrics.Zombies = append(stateMetrics.Zombies, &pb.ZombieProcess{Pid: z.PID, Name: z.Name, Ppid: z.PPID, ParentName: z.ParentName})
+		}
+		for _, s := range states.StuckDState {
+			stateMetrics.StuckDState = append(stateMetrics.StuckDState, &pb.StuckProcess{Pid: s.PID, Name: s.Name, Seconds: s.Seconds})
+		}
+		for _, l := range states.LeakSuspects {
+			stateMetrics.LeakSuspects = append(stateMetrics.LeakSuspects, &pb.LeakSuspect{
+				Pid:         l.PID,
+				Name:        l.Name,
+				RssBytes:    l.RSSBytes,
+				GrowthBytes: l.GrowthBytes,
+				Samples:     int32(l.Samples),
+			})
+		}
+		req.ProcessStates = stateMetrics
+	}
+
 	return req
 }
 
+func listeningSocketProto(socket ListeningSocket) *pb.ListeningSocket {
+	return &pb.ListeningSocket{
+		Protocol: socket.Protocol,
+		Address:  socket.Address,
+		Port:     int32(socket.Port),
+		Pid:      socket.PID,
+		Process:  socket.Process,
+		Uid:      int32(socket.UID),
+	}
+}
+
+func pressureResourceProto(resource PressureResource) *pb.PressureResource {
+	stat := func(s PressureStat) *pb.PressureStat {
+		return &pb.PressureStat{Avg10: s.Avg10, Avg60: s.Avg60, Avg300: s.Avg300, TotalUsec: s.TotalUsec}
+	}
+	return &pb.PressureResource{Some: stat(resource.Some), Full: stat(resource.Full)}
+}
+
 func (r *Reporter) sendMetricsRequest(req *pb.MetricsRequest) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeoutSeconds(r.config.GRPC.ReportTimeout, 5))
 	defer cancel()
@@ -349,6 +598,30 @@ func (r *Reporter) ReportProcesses(data *ProcessMetrics) error {
 			CreateTime:    p.CreateTime,
 			Status:        p.Status,
 			Command:       p.Command,
+			Watched:       p.Watched,
+
+			Ppid:                   p.PPID,
+			NumThreads:             p.NumThreads,
+			NumFds:                 p.NumFDs,
+			ReadBytesPerSec:        p.ReadBytesPerSec,
+			WriteBytesPerSec:       p.WriteBytesPerSec,
+			VoluntaryCtxSwitches:   p.VoluntaryCtxSwitches,
+			InvoluntaryCtxSwitches: p.InvoluntaryCtxSwitches,
+			Nice:                   p.Nice,
+			CgroupPath:             p.CgroupPath,
+			ContainerId:            p.ContainerID,
+			Unit:                   p.Unit,
+		})
+	}
+
+	for _, g := range data.Groups {
+		req.Groups = append(req.Groups, &pb.ProcessGroup{
+			By:          g.By,
+			Key:         g.Key,
+			Count:       int32(g.Count),
+			CpuPercent:  g.CPUPercent,
+			MemoryBytes: g.MemoryBytes,
+			NumFds:      g.NumFDs,
 		})
 	}
 
@@ -372,10 +645,14 @@ func (r *Reporter) ReportProcesses(data *ProcessMetrics) error {
 	return nil
 }
 
+// errReporterNotRegistered 尚未注册时跳过上报，调用方不记录为失败
+var errReporterNotRegistered = errors.New("reporter not registered")
+
 // ReportLogs 上报日志数据
+// 未注册时返回 errReporterNotRegistered，调用方据此保留日志读取位置，下个周期重新读取
 func (r *Reporter) ReportLogs(data *LogMetrics) error {
 	if !r.registered {
-		return nil
+		return errReporterNotRegistered
 	}
 
 	req := &pb.LogReportRequest{
@@ -457,6 +734,9 @@ func (r *Reporter) ReportServiceStatus(data *ServiceMetrics) error {
 			Enabled:       s.Enabled,
 			Description:   s.Description,
 			UptimeSeconds: s.Uptime,
+			Source:        s.Source,
+			Instances:     int32(s.Instances),
+			Pids:          s.PIDs,
 		}
 		// 如果有端口信息，添加端口和端口检查结果
 		if s.Port > 0 {
@@ -18,36 +18,90 @@ type Collector interface {
 
 // CPUMetrics CPU指标
 type CPUMetrics struct {
-	UsagePercent float64 `json:"usage_percent"`
-	LoadAvg1     float64 `json:"load_avg_1"`
-	LoadAvg5     float64 `json:"load_avg_5"`
-	LoadAvg15    float64 `json:"load_avg_15"`
-	CoreCount    int     `json:"core_count"`
+	UsagePercent   float64   `json:"usage_percent"`
+	LoadAvg1       float64   `json:"load_avg_1"`
+	LoadAvg5       float64   `json:"load_avg_5"`
+	LoadAvg15      float64   `json:"load_avg_15"`
+	CoreCount      int       `json:"core_count"`
+	PerCorePercent []float64 `json:"per_core_percent"`
+	UserPercent    float64   `json:"user_percent"`
+	SystemPercent  float64   `json:"system_percent"`
+	NicePercent    float64   `json:"nice_percent"`
+	IowaitPercent  float64   `json:"iowait_percent"`
+	IrqPercent     float64   `json:"irq_percent"`
+	SoftirqPercent float64   `json:"softirq_percent"`
+	StealPercent   float64   `json:"steal_percent"`
+	GuestPercent   float64   `json:"guest_percent"`
 }
 
 // MemoryMetrics 内存指标
 type MemoryMetrics struct {
-	Total       uint64  `json:"total"`
-	Used        uint64  `json:"used"`
-	Free        uint64  `json:"free"`
-	UsedPercent float64 `json:"used_percent"`
-	Available   uint64  `json:"available"`
+	Total                uint64  `json:"total"`
+	Used                 uint64  `json:"used"`
+	Free                 uint64  `json:"free"`
+	UsedPercent          float64 `json:"used_percent"`
+	Available            uint64  `json:"available"`
+	SwapTotal            uint64  `json:"swap_total"`
+	SwapUsed             uint64  `json:"swap_used"`
+	SwapFree             uint64  `json:"swap_free"`
+	SwapUsedPercent      float64 `json:"swap_used_percent"`
+	Buffers              uint64  `json:"buffers"`
+	Cached               uint64  `json:"cached"`
+	Shared               uint64  `json:"shared"`
+	SlabReclaimable      uint64  `json:"slab_reclaimable"`
+	SlabUnreclaimable    uint64  `json:"slab_unreclaimable"`
+	Dirty                uint64  `json:"dirty"`
+	Writeback            uint64  `json:"writeback"`
+	CommittedAS          uint64  `json:"committed_as"`
+	CommitLimit          uint64  `json:"commit_limit"`
+	CommitPercent        float64 `json:"commit_percent"`
+	HugePagesTotal       uint64  `json:"huge_pages_total"`
+	HugePagesFree        uint64  `json:"huge_pages_free"`
+	HugePagesReserved    uint64  `json:"huge_pages_reserved"`
+	HugePageSize         uint64  `json:"huge_page_size"`
+	HugePagesUsedPercent float64 `json:"huge_pages_used_percent"`
+	AnonHugePages        uint64  `json:"anon_huge_pages"`
 }
 
 // DiskMetrics 磁盘指标
 type DiskMetrics struct {
 	Partitions []PartitionMetrics `json:"partitions"`
+	IO         []DiskIOMetrics    `json:"io"`
 }
 
 // PartitionMetrics 分区指标
 type PartitionMetrics struct {
-	Device      string  `json:"device"`
-	Mountpoint  string  `json:"mountpoint"`
-	Fstype      string  `json:"fstype"`
-	Total       uint64  `json:"total"`
-	Used        uint64  `json:"used"`
-	Free        uint64  `json:"free"`
-	UsedPercent float64 `json:"used_percent"`
+	Device            string  `json:"device"`
+	Mountpoint        string  `json:"mountpoint"`
+	Fstype            string  `json:"fstype"`
+	Total             uint64  `json:"total"`
+	Used              uint64  `json:"used"`
+	Free              uint64  `json:"free"`
+	UsedPercent       float64 `json:"used_percent"`
+	InodesTotal       uint64  `json:"inodes_total"`
+	InodesUsed        uint64  `json:"inodes_used"`
+	InodesFree        uint64  `json:"inodes_free"`
+	InodesUsedPercent float64 `json:"inodes_used_percent"`
+	// 按历史趋势预测，SecondsUntilFull 为 -1 表示数据不足或未增长
+	GrowthBytesPerSecond float64 `json:"growth_bytes_per_second"`
+	SecondsUntilFull     int64   `json:"seconds_until_full"`
+}
+
+// DiskIOMetrics 块设备IO指标，按相邻两次采集的计数差值计算
+type DiskIOMetrics struct {
+	Device           string   `json:"device"`
+	Label            string   `json:"label"`
+	Mountpoints      []string `json:"mountpoints"`
+	ReadBytesPerSec  float64  `json:"read_bytes_per_sec"`
+	WriteBytesPerSec float64  `json:"write_bytes_per_sec"`
+	ReadIOPS         float64  `json:"read_iops"`
+	WriteIOPS        float64  `json:"write_iops"`
+	ReadAwaitMs      float64  `json:"read_await_ms"`
+	WriteAwaitMs     float64  `json:"write_await_ms"`
+	AwaitMs          float64  `json:"await_ms"`
+	QueueDepth       float64  `json:"queue_depth"`
+	UtilPercent      float64  `json:"util_percent"`
+	IopsInProgress   uint64   `json:"iops_in_progress"`
 }
 
 // NetworkMetrics 网络指标
@@ -64,6 +118,25 @@ type InterfaceMetrics struct {
 	PacketsRecv uint64 `json:"packets_recv"`
 	Errin       uint64 `json:"errin"`
 	Errout      uint64 `json:"errout"`
+	Dropin      uint64 `json:"dropin"`
+	Dropout     uint64 `json:"dropout"`
+	// 每秒速率，首次采集为0
+	BytesSentPerSec   float64 `json:"bytes_sent_per_sec"`
+	BytesRecvPerSec   float64 `json:"bytes_recv_per_sec"`
+	PacketsSentPerSec float64 `json:"packets_sent_per_sec"`
+	PacketsRecvPerSec float64 `json:"packets_recv_per_sec"`
+	ErrinPerSec       float64 `json:"errin_per_sec"`
+	ErroutPerSec      float64 `json:"errout_per_sec"`
+	DropinPerSec      float64 `json:"dropin_per_sec"`
+	DropoutPerSec     float64 `json:"dropout_per_sec"`
+	// 来自 /sys/class/net 的网卡属性，SpeedMbps 为0表示未知（虚拟网卡）
+	OperState          string   `json:"oper_state"`
+	SpeedMbps          int64    `json:"speed_mbps"`
+	Duplex             string   `json:"duplex"`
+	MTU                int      `json:"mtu"`
+	HardwareAddr       string   `json:"hardware_addr"`
+	Addresses          []string `json:"addresses"`
+	UtilizationPercent float64  `json:"utilization_percent"`
 }
 
 // GPUMetrics GPU指标
@@ -87,3 +160,176 @@ type GPUDeviceMetrics struct {
 	PowerWatts         float64 `json:"power_watts"`
 	FanSpeedPercent    float64 `json:"fan_speed_percent"`
 }
+
+// CgroupMetrics cgroup v2 工作负载指标
+type CgroupMetrics struct {
+	Supported bool         `json:"supported"`
+	Groups    []CgroupInfo `json:"groups"`
+}
+
+// CgroupInfo 单个 systemd 服务或容器 scope 的资源使用
+type CgroupInfo struct {
+	Path                    string  `json:"path"`
+	Kind                    string  `json:"kind"`
+	Name                    string  `json:"name"`
+	ContainerID             string  `json:"container_id,omitempty"`
+	Unit                    string  `json:"unit,omitempty"`
+	CPUUsageUsec            uint64  `json:"cpu_usage_usec"`
+	CPUThrottledUsec        uint64  `json:"cpu_throttled_usec"`
+	CPUPercent              float64 `json:"cpu_percent"`
+	MemoryCurrent           uint64  `json:"memory_current"`
+	MemoryMax               uint64  `json:"memory_max"`
+	MemoryPercent           float64 `json:"memory_percent"`
+	MemoryPressureSomeAvg10 float64 `json:"memory_pressure_some_avg10"`
+	MemoryPressureFullAvg10 float64 `json:"memory_pressure_full_avg10"`
+	CPUPressureSomeAvg10    float64 `json:"cpu_pressure_some_avg10"`
+	IOPressureSomeAvg10     float64 `json:"io_pressure_some_avg10"`
+	IOPressureFullAvg10     float64 `json:"io_pressure_full_avg10"`
+	IOReadBytes             uint64  `json:"io_read_bytes"`
+	IOWriteBytes            uint64  `json:"io_write_bytes"`
+	IOReadBytesPerSec       float64 `json:"io_read_bytes_per_sec"`
+	IOWriteBytesPerSec      float64 `json:"io_write_bytes_per_sec"`
+	PidsCurrent             uint64  `json:"pids_current"`
+}
+
+// PressureMetrics Linux PSI 压力指标
+type PressureMetrics struct {
+	Supported bool             `json:"supported"`
+	CPU       PressureResource `json:"cpu"`
+	Memory    PressureResource `json:"memory"`
+	IO        PressureResource `json:"io"`
+}
+
+// PressureResource 单类资源的 some/full 压力
+type PressureResource struct {
+	Some PressureStat `json:"some"`
+	Full PressureStat `json:"full"`
+}
+
+// PressureStat 压力百分比均值和累计停顿时间（微秒）
+type PressureStat struct {
+	Avg10     float64 `json:"avg10"`
+	Avg60     float64 `json:"avg60"`
+	Avg300    float64 `json:"avg300"`
+	TotalUsec uint64  `json:"total_usec"`
+}
+
+// NetstackMetrics 协议栈状态：TCP 连接按状态计数，协议计数器为每秒速率
+type NetstackMetrics struct {
+	TCPStates    map[string]uint64 `json:"tcp_states"` // ESTABLISHED、TIME_WAIT 等，IPv4 和 IPv6 合计
+	TCPSockets   uint64            `json:"tcp_sockets"`
+	UDPSockets   uint64            `json:"udp_sockets"`
+	TCPCurrEstab uint64            `json:"tcp_curr_estab"`
+
+	TCPActiveOpensPerSec   float64 `json:"tcp_active_opens_per_sec"`
+	TCPPassiveOpensPerSec  float64 `json:"tcp_passive_opens_per_sec"`
+	TCPAttemptFailsPerSec  float64 `json:"tcp_attempt_fails_per_sec"`
+	TCPEstabResetsPerSec   float64 `json:"tcp_estab_resets_per_sec"`
+	TCPInErrsPerSec        float64 `json:"tcp_in_errs_per_sec"`
+	TCPOutRstsPerSec       float64 `json:"tcp_out_rsts_per_sec"`
+	TCPOutSegsPerSec       float64 `json:"tcp_out_segs_per_sec"`
+	TCPRetransSegsPerSec   float64 `json:"tcp_retrans_segs_per_sec"`
+	TCPRetransPercent      float64 `json:"tcp_retrans_percent"`
+	ListenOverflowsPerSec  float64 `json:"listen_overflows_per_sec"`
+	ListenDropsPerSec      float64 `json:"listen_drops_per_sec"`
+	SyncookiesSentPerSec   float64 `json:"syncookies_sent_per_sec"`
+	SyncookiesRecvPerSec   float64 `json:"syncookies_recv_per_sec"`
+	SyncookiesFailedPerSec float64 `json:"syncookies_failed_per_sec"`
+	UDPInErrorsPerSec      float64 `json:"udp_in_errors_per_sec"`
+	UDPNoPortsPerSec       float64 `json:"udp_no_ports_per_sec"`
+	UDPRcvbufErrorsPerSec  float64 `json:"udp_rcvbuf_errors_per_sec"`
+	UDPSndbufErrorsPerSec  float64 `json:"udp_sndbuf_errors_per_sec"`
+}
+
+// ListenerMetrics 监听端口清单和本周期的变化事件
+type ListenerMetrics struct {
+	Sockets []ListeningSocket `json:"sockets"`
+	Events  []ListenerEvent   `json:"events"`
+}
+
+// ListeningSocket 监听中的 TCP/UDP 套接字，PID 为0表示无法确定所属进程
+type ListeningSocket struct {
+	Protocol string `json:"protocol"` // tcp、tcp6、udp、udp6
+	Address  string `json:"address"`
+	Port     int    `json:"port"`
+	PID      int32  `json:"pid"`
+	Process  string `json:"process"`
+	UID      int    `json:"uid"`
+}
+
+// ListenerEvent 监听端口变化，Type 为 new（新增监听）或 gone（监听消失）
+type ListenerEvent struct {
+	Type      string          `json:"type"`
+	Socket    ListeningSocket `json:"socket"`
+	Timestamp int64           `json:"timestamp"`
+}
+
+// LimitsMetrics 系统资源上限使用情况，ConntrackSupported 为 false 表示未加载 nf_conntrack
+type LimitsMetrics struct {
+	ConntrackSupported   bool             `json:"conntrack_supported"`
+	ConntrackCount       uint64           `json:"conntrack_count"`
+	ConntrackMax         uint64           `json:"conntrack_max"`
+	ConntrackPercent     float64          `json:"conntrack_percent"`
+	FileHandlesAllocated uint64           `json:"file_handles_allocated"`
+	FileHandlesMax       uint64           `json:"file_handles_max"`
+	FileHandlesPercent   float64          `json:"file_handles_percent"`
+	Processes            []ProcessFDUsage `json:"processes"` // 打开文件数占软限制比例最高的进程
+}
+
+// ProcessFDUsage 进程打开文件数与 RLIMIT_NOFILE，限制为0表示 unlimited
+type ProcessFDUsage struct {
+	PID       int32   `json:"pid"`
+	Name      string  `json:"name"`
+	OpenFDs   uint64  `json:"open_fds"`
+	SoftLimit uint64  `json:"soft_limit"`
+	HardLimit uint64  `json:"hard_limit"`
+	Percent   float64 `json:"percent"`
+}
+
+// ProcessStateMetrics 全机进程状态汇总
+type ProcessStateMetrics struct {
+	Total        int             `json:"total"`
+	States       map[string]int  `json:"states"` // R、S、D、Z、T、I 等状态的进程数
+	Zombies      []ZombieProcess `json:"zombies"`
+	StuckDState  []StuckProcess  `json:"stuck_d_state"` // 持续处于 D 状态超过阈值
+	LeakSuspects []LeakSuspect   `json:"leak_suspects"` // RSS 连续多个周期持续增长
+}
+
+type ZombieProcess struct {
+	PID        int32  `json:"pid"`
+	Name       string `json:"name"`
+	PPID       int32  `json:"ppid"`
+	ParentName string `json:"parent_name"`
+}
+
+type StuckProcess struct {
+	PID     int32  `json:"pid"`
+	Name    string `json:"name"`
+	Seconds int64  `json:"seconds"` // 已连续处于 D 状态的时长
+}
+
+type LeakSuspect struct {
+	PID         int32  `json:"pid"`
+	Name        string `json:"name"`
+	RSSBytes    uint64 `json:"rss_bytes"`
+	GrowthBytes uint64 `json:"growth_bytes"` // 检测窗口内的 RSS 增长
+	Samples     int    `json:"samples"`
+}
+
+// KernelMetrics 内核活动计数器，速率按相邻两次采集计算
+type KernelMetrics struct {
+	ContextSwitchesPerSec float64 `json:"context_switches_per_sec"`
+	InterruptsPerSec      float64 `json:"interrupts_per_sec"`
+	ForksPerSec           float64 `json:"forks_per_sec"`
+	ProcsRunning          uint64  `json:"procs_running"`
+	ProcsBlocked          uint64  `json:"procs_blocked"`
+	PageFaultsPerSec      float64 `json:"page_faults_per_sec"`
+	MajorPageFaultsPerSec float64 `json:"major_page_faults_per_sec"`
+	SwapInPagesPerSec     float64 `json:"swap_in_pages_per_sec"`
+	SwapOutPagesPerSec    float64 `json:"swap_out_pages_per_sec"`
+	PageScanKswapdPerSec  float64 `json:"page_scan_kswapd_per_sec"`
+	PageScanDirectPerSec  float64 `json:"page_scan_direct_per_sec"`
+	PageStealPerSec       float64 `json:"page_steal_per_sec"`
+	OOMKills              uint64  `json:"oom_kills"`
+	OOMKillsDelta         uint64  `json:"oom_kills_delta"`
+}